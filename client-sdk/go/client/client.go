@@ -2,9 +2,14 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
@@ -22,15 +27,63 @@ import (
 // RoundLatest is a special round number always referring to the latest round.
 const RoundLatest = coreClient.RoundLatest
 
+// InfoRefreshInterval is the interval after which cached runtime information is re-fetched.
+const InfoRefreshInterval = 10 * time.Minute
+
+// ErrStreamingUnsupported is the error returned when the node does not support the requested
+// streaming method.
+var ErrStreamingUnsupported = errors.New("streaming method not supported by node")
+
+// ErrChainContextMismatch is the error returned when the node's chain context does not match the
+// expected chain context configured via WithExpectedChainContext.
+var ErrChainContextMismatch = errors.New("chain context mismatch")
+
+// ErrRuntimeNotActive is the error returned when the runtime has not yet produced its genesis
+// block and can therefore not serve any requests. The concrete error returned is a
+// *RuntimeNotActiveError which matches ErrRuntimeNotActive when using errors.Is.
+var ErrRuntimeNotActive = errors.New("runtime not active yet")
+
+// RuntimeNotActiveError is the error returned when the runtime has not yet produced its genesis
+// block.
+type RuntimeNotActiveError struct {
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the string representation of the error.
+func (e *RuntimeNotActiveError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrRuntimeNotActive, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RuntimeNotActiveError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true iff the target is ErrRuntimeNotActive.
+func (e *RuntimeNotActiveError) Is(target error) bool {
+	return target == ErrRuntimeNotActive
+}
+
 // RuntimeClient is a client interface for runtimes based on the Oasis Runtime SDK.
 type RuntimeClient interface {
 	// GetInfo returns information about the runtime.
+	//
+	// The information is cached and only re-fetched from the node after InfoRefreshInterval has
+	// elapsed.
 	GetInfo(ctx context.Context) (*types.RuntimeInfo, error)
 
 	// SubmitTx submits a transaction to the runtime transaction scheduler and waits
 	// for transaction execution results.
 	SubmitTx(ctx context.Context, tx *types.UnverifiedTransaction) (cbor.RawMessage, error)
 
+	// SubmitVerifiedTx verifies the transaction's signatures against its declared signers and
+	// the runtime's chain context locally and, in case verification succeeds, submits it to the
+	// runtime transaction scheduler and waits for transaction execution results.
+	//
+	// The transaction body is additionally checked to be canonically CBOR-encoded.
+	SubmitVerifiedTx(ctx context.Context, tx *types.UnverifiedTransaction) (cbor.RawMessage, error)
+
 	// SubmitTxNoWait submits a transaction to the runtime transaction scheduler but does
 	// not wait for transaction execution.
 	SubmitTxNoWait(ctx context.Context, tx *types.UnverifiedTransaction) error
@@ -41,17 +94,87 @@ type RuntimeClient interface {
 	// GetBlock fetches the given runtime block.
 	GetBlock(ctx context.Context, round uint64) (*block.Block, error)
 
+	// WaitForRound waits for the runtime to reach the given round and returns the corresponding
+	// block. This may be used to wait for a runtime that is not yet active.
+	WaitForRound(ctx context.Context, round uint64) (*block.Block, error)
+
+	// GetRoundMetadata returns a typed view of the runtime activity in the given round.
+	GetRoundMetadata(ctx context.Context, round uint64) (*RoundMetadata, error)
+
 	// GetTransactions returns all transactions that are part of a given block.
 	GetTransactions(ctx context.Context, round uint64) ([]*types.UnverifiedTransaction, error)
 
+	// GetTransactionsBySigner returns all transactions that are part of a given block and where
+	// the given address is one of the signers (for multisig signers, this is the address derived
+	// from the multisig configuration).
+	GetTransactionsBySigner(ctx context.Context, round uint64, address types.Address) ([]*types.UnverifiedTransaction, error)
+
 	// GetEvents returns all events emitted in a given block.
 	GetEvents(ctx context.Context, round uint64) ([]*coreClient.Event, error)
 
+	// GetEventsRange returns all events emitted in the given (inclusive) range of rounds, decoded
+	// using the given decoders.
+	//
+	// Events are streamed round by round over the returned channel. In case of failure the error
+	// is sent over the error channel. In case the range ends beyond the latest round, a
+	// *FutureRoundError is sent before any events are fetched, while in case a round in the range
+	// has been pruned, a *RoundPrunedError is sent. Both channels are closed once the replay
+	// finishes.
+	GetEventsRange(ctx context.Context, fromRound, toRound uint64, decoders []EventDecoder) (<-chan *RoundEvents, <-chan error)
+
 	// WatchBlocks subscribes to blocks for a specific runtimes.
+	//
+	// In case the node rejects the subscription as unimplemented, ErrStreamingUnsupported is
+	// returned.
 	WatchBlocks(ctx context.Context) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error)
 
+	// WatchBlocksAndFetchTransactions subscribes to blocks for a specific runtime and delivers
+	// each block together with its decoded transactions.
+	//
+	// This is a client-side convenience wrapper around WatchBlocks. The node's block subscription
+	// does not include transactions, so they are fetched with a separate GetTransactions request
+	// for each block as it is received. In case fetching fails, the error is sent over the error
+	// channel. Both channels are closed once the watch stops.
+	WatchBlocksAndFetchTransactions(ctx context.Context) (<-chan *BlockWithTransactions, <-chan error, pubsub.ClosableSubscription, error)
+
+	// WatchFinalizedBlocks subscribes to blocks for a specific runtime and only delivers each
+	// block once the given number of subsequent rounds have been observed on top of it.
+	//
+	// Note that runtime blocks are committed via the consensus layer which provides instant
+	// finality, so blocks delivered by WatchBlocks are never reverted and confirmations set to
+	// zero behave the same as WatchBlocks. A non-zero number of confirmations can be used by
+	// applications that want to additionally delay processing (e.g., to only act on state that
+	// has been superseded by later rounds).
+	WatchFinalizedBlocks(ctx context.Context, confirmations uint64) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error)
+
 	// Query makes a runtime-specific query.
 	Query(ctx context.Context, round uint64, method string, args, rsp interface{}) error
+
+	// QueryAt makes a runtime-specific query at the round given by the round selector.
+	//
+	// In case a specific round is selected which is no longer available, a *RoundPrunedError is
+	// returned. In case it is beyond the latest round, a *FutureRoundError is returned.
+	QueryAt(ctx context.Context, when RoundSelector, method string, args, rsp interface{}) error
+
+	// Snapshot performs all of the given queries against the same round (in parallel) and
+	// returns their results keyed by each ReadSpec's key. In case the latest round is selected,
+	// it is first resolved to a concrete round so that all results are consistent.
+	Snapshot(ctx context.Context, when RoundSelector, reads ...ReadSpec) (*Snapshot, error)
+
+	// HealthCheck verifies that the node is ready to serve the runtime. It checks that the node
+	// serves the expected runtime, that the chain context matches the expected chain context (if
+	// non-empty) and that the latest runtime round is recent and keeps advancing between
+	// subsequent health checks.
+	HealthCheck(ctx context.Context, expectedChainContext signature.Context) (*HealthStatus, error)
+}
+
+// InfoRefresher is an optional interface implemented by runtime clients which cache runtime
+// information and support re-fetching it on demand. Callers should type-assert the
+// RuntimeClient to check whether it is supported.
+type InfoRefresher interface {
+	// RefreshInfo invalidates the cached runtime information and re-fetches it from the node
+	// (e.g., after a known network upgrade).
+	RefreshInfo(ctx context.Context) (*types.RuntimeInfo, error)
 }
 
 // Event is an event emitted by a runtime in the form of a runtime transaction tag.
@@ -65,29 +188,91 @@ type Event struct {
 	Value  cbor.RawMessage
 }
 
+// BlockWithTransactions is a runtime block together with its transactions.
+type BlockWithTransactions struct {
+	// Block is the annotated runtime block.
+	Block *roothash.AnnotatedBlock
+	// Transactions are the transactions included in the block.
+	Transactions []*types.UnverifiedTransaction
+}
+
+// RoundMetadata is the metadata of a runtime round.
+type RoundMetadata struct {
+	// Round is the runtime round.
+	Round uint64
+	// Timestamp is the block timestamp (POSIX time).
+	Timestamp uint64
+	// IORoot is the I/O merkle root.
+	IORoot hash.Hash
+	// StateRoot is the state merkle root.
+	StateRoot hash.Hash
+	// MessagesHash is the hash of the consensus messages emitted in the round.
+	MessagesHash hash.Hash
+	// MessageCount is the number of consensus messages emitted in the round.
+	//
+	// Note that this runtime does not receive any incoming messages from the consensus layer, so
+	// only emitted (outgoing) messages are counted.
+	MessageCount int
+	// TransactionCount is the number of transactions included in the round.
+	TransactionCount int
+	// EventCount is the number of events emitted in the round.
+	EventCount int
+}
+
 type runtimeClient struct {
 	cs consensus.ClientBackend
 	cc coreClient.RuntimeClient
 
-	runtimeID   common.Namespace
-	runtimeInfo *types.RuntimeInfo
+	runtimeID common.Namespace
+
+	infoLock           sync.Mutex
+	runtimeInfo        *types.RuntimeInfo
+	runtimeInfoFetched time.Time
+
+	healthLock      sync.Mutex
+	healthRound     uint64
+	healthRoundSeen time.Time
+
+	expectedChainContext signature.Context
+
+	queryRetry *RetryOptions
 }
 
 // Implements RuntimeClient.
 func (rc *runtimeClient) GetInfo(ctx context.Context) (*types.RuntimeInfo, error) {
-	if rc.runtimeInfo != nil {
+	rc.infoLock.Lock()
+	defer rc.infoLock.Unlock()
+
+	if rc.runtimeInfo != nil && time.Since(rc.runtimeInfoFetched) < InfoRefreshInterval {
 		return rc.runtimeInfo, nil
 	}
+	return rc.fetchInfoLocked(ctx)
+}
 
+// Implements InfoRefresher.
+func (rc *runtimeClient) RefreshInfo(ctx context.Context) (*types.RuntimeInfo, error) {
+	rc.infoLock.Lock()
+	defer rc.infoLock.Unlock()
+
+	return rc.fetchInfoLocked(ctx)
+}
+
+func (rc *runtimeClient) fetchInfoLocked(ctx context.Context) (*types.RuntimeInfo, error) {
 	chainCtx, err := rc.cs.GetChainContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch consensus layer chain context: %w", err)
 	}
 
-	rc.runtimeInfo = &types.RuntimeInfo{
+	info := &types.RuntimeInfo{
 		ID:           rc.runtimeID,
 		ChainContext: signature.DeriveChainContext(rc.runtimeID, chainCtx),
 	}
+	if rc.expectedChainContext != "" && info.ChainContext != rc.expectedChainContext {
+		return nil, fmt.Errorf("%w (expected: %s got: %s)", ErrChainContextMismatch, rc.expectedChainContext, info.ChainContext)
+	}
+
+	rc.runtimeInfo = info
+	rc.runtimeInfoFetched = time.Now()
 	return rc.runtimeInfo, nil
 }
 
@@ -111,6 +296,21 @@ func (rc *runtimeClient) SubmitTx(ctx context.Context, tx *types.UnverifiedTrans
 	return result.Ok, nil
 }
 
+// Implements RuntimeClient.
+func (rc *runtimeClient) SubmitVerifiedTx(ctx context.Context, tx *types.UnverifiedTransaction) (cbor.RawMessage, error) {
+	rtInfo, err := rc.GetInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve runtime info: %w", err)
+	}
+	if err = types.ValidateCanonicalCBOR(tx.Body); err != nil {
+		return nil, fmt.Errorf("local transaction verification failed: %w", err)
+	}
+	if _, err = tx.Verify(rtInfo.ChainContext); err != nil {
+		return nil, fmt.Errorf("local transaction verification failed: %w", err)
+	}
+	return rc.SubmitTx(ctx, tx)
+}
+
 // Implements RuntimeClient.
 func (rc *runtimeClient) SubmitTxNoWait(ctx context.Context, tx *types.UnverifiedTransaction) error {
 	return rc.cc.SubmitTxNoWait(ctx, &coreClient.SubmitTxRequest{
@@ -119,22 +319,225 @@ func (rc *runtimeClient) SubmitTxNoWait(ctx context.Context, tx *types.Unverifie
 	})
 }
 
+// streamingError maps the given streaming method error, returning ErrStreamingUnsupported in case
+// the node does not implement the method.
+func streamingError(err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return fmt.Errorf("%w: %s", ErrStreamingUnsupported, err)
+	}
+	return err
+}
+
 // Implements RuntimeClient.
 func (rc *runtimeClient) WatchBlocks(ctx context.Context) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
-	return rc.cc.WatchBlocks(ctx, rc.runtimeID)
+	ch, sub, err := rc.cc.WatchBlocks(ctx, rc.runtimeID)
+	if err != nil {
+		return nil, nil, streamingError(err)
+	}
+	return ch, sub, nil
+}
+
+// Implements RuntimeClient.
+func (rc *runtimeClient) WatchBlocksAndFetchTransactions(ctx context.Context) (<-chan *BlockWithTransactions, <-chan error, pubsub.ClosableSubscription, error) {
+	blkCh, blkSub, err := rc.WatchBlocks(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ch := make(chan *BlockWithTransactions)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		defer close(errCh)
+
+		for {
+			var blk *roothash.AnnotatedBlock
+			var ok bool
+			select {
+			case blk, ok = <-blkCh:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			txs, err := rc.GetTransactions(ctx, blk.Block.Header.Round)
+			if err != nil {
+				errCh <- fmt.Errorf("failed to fetch transactions for round %d: %w", blk.Block.Header.Round, err)
+				return
+			}
+
+			select {
+			case ch <- &BlockWithTransactions{Block: blk, Transactions: txs}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, errCh, blkSub, nil
+}
+
+// checkActive maps a not found error for a request at the given round to a
+// *RuntimeNotActiveError in case the runtime has not yet produced its genesis block. Other
+// errors are returned unchanged.
+//
+// Only requests for the latest or the genesis round are checked as not found errors for other
+// rounds do not indicate an inactive runtime, which avoids an additional request.
+func (rc *runtimeClient) checkActive(ctx context.Context, round uint64, err error) error {
+	if !isNotFound(err) || (round != RoundLatest && round != 0) {
+		return err
+	}
+	if _, gerr := rc.cc.GetGenesisBlock(ctx, rc.runtimeID); gerr != nil && isNotFound(gerr) {
+		return &RuntimeNotActiveError{Err: err}
+	}
+	return err
+}
+
+// Implements RuntimeClient.
+func (rc *runtimeClient) WatchFinalizedBlocks(ctx context.Context, confirmations uint64) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	blkCh, blkSub, err := rc.WatchBlocks(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *roothash.AnnotatedBlock)
+	go func() {
+		defer close(ch)
+
+		var pending []*roothash.AnnotatedBlock
+		for {
+			var blk *roothash.AnnotatedBlock
+			var ok bool
+			select {
+			case blk, ok = <-blkCh:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+			pending = append(pending, blk)
+
+			// Deliver all blocks that have enough confirmations.
+			round := blk.Block.Header.Round
+			for len(pending) > 0 && pending[0].Block.Header.Round+confirmations <= round {
+				select {
+				case ch <- pending[0]:
+				case <-ctx.Done():
+					return
+				}
+				pending = pending[1:]
+			}
+		}
+	}()
+
+	return ch, blkSub, nil
 }
 
 // Implements RuntimeClient.
 func (rc *runtimeClient) GetGenesisBlock(ctx context.Context) (*block.Block, error) {
-	return rc.cc.GetGenesisBlock(ctx, rc.runtimeID)
+	blk, err := rc.cc.GetGenesisBlock(ctx, rc.runtimeID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, &RuntimeNotActiveError{Err: err}
+		}
+		return nil, err
+	}
+	return blk, nil
 }
 
 // Implements RuntimeClient.
 func (rc *runtimeClient) GetBlock(ctx context.Context, round uint64) (*block.Block, error) {
-	return rc.cc.GetBlock(ctx, &coreClient.GetBlockRequest{
+	blk, err := rc.cc.GetBlock(ctx, &coreClient.GetBlockRequest{
 		RuntimeID: rc.runtimeID,
 		Round:     round,
 	})
+	if err != nil {
+		return nil, rc.checkActive(ctx, round, err)
+	}
+	return blk, nil
+}
+
+// Implements RuntimeClient.
+func (rc *runtimeClient) WaitForRound(ctx context.Context, round uint64) (*block.Block, error) {
+	// Subscribe first so that no blocks are missed between the check and the subscription.
+	blkCh, blkSub, err := rc.WatchBlocks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to blocks: %w", err)
+	}
+	defer blkSub.Close()
+
+	blk, err := rc.GetBlock(ctx, RoundLatest)
+	switch {
+	case err == nil:
+		if blk.Header.Round >= round {
+			return rc.GetBlock(ctx, round)
+		}
+	case errors.Is(err, ErrRuntimeNotActive):
+		// Runtime not yet active, wait for blocks.
+	default:
+		return nil, fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case annBlk, ok := <-blkCh:
+			if !ok {
+				return nil, fmt.Errorf("block subscription closed")
+			}
+			switch {
+			case annBlk.Block.Header.Round == round:
+				return annBlk.Block, nil
+			case annBlk.Block.Header.Round > round:
+				return rc.GetBlock(ctx, round)
+			}
+		}
+	}
+}
+
+// methodCoreEmittedMessages is the core module's emitted messages query method. The core module
+// client cannot be used here as it imports this package.
+const methodCoreEmittedMessages = "core.EmittedMessages"
+
+// Implements RuntimeClient.
+func (rc *runtimeClient) GetRoundMetadata(ctx context.Context, round uint64) (*RoundMetadata, error) {
+	blk, err := rc.GetBlock(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block for round %d: %w", round, err)
+	}
+	// Make sure the remaining queries refer to the same round in case latest was requested.
+	round = blk.Header.Round
+
+	txs, err := rc.GetTransactions(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transactions for round %d: %w", round, err)
+	}
+	events, err := rc.GetEvents(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch events for round %d: %w", round, err)
+	}
+	// Only normal rounds are executed by the runtime and can emit messages.
+	var messageCount uint32
+	if blk.Header.HeaderType == block.Normal {
+		if err = rc.Query(ctx, round, methodCoreEmittedMessages, nil, &messageCount); err != nil {
+			return nil, fmt.Errorf("failed to query emitted messages for round %d: %w", round, err)
+		}
+	}
+
+	return &RoundMetadata{
+		Round:            round,
+		Timestamp:        blk.Header.Timestamp,
+		IORoot:           blk.Header.IORoot,
+		StateRoot:        blk.Header.StateRoot,
+		MessagesHash:     blk.Header.MessagesHash,
+		MessageCount:     int(messageCount),
+		TransactionCount: len(txs),
+		EventCount:       len(events),
+	}, nil
 }
 
 // Implements RuntimeClient.
@@ -163,6 +566,33 @@ func (rc *runtimeClient) GetTransactions(ctx context.Context, round uint64) ([]*
 	return txs, nil
 }
 
+// Implements RuntimeClient.
+func (rc *runtimeClient) GetTransactionsBySigner(ctx context.Context, round uint64, address types.Address) ([]*types.UnverifiedTransaction, error) {
+	txs, err := rc.GetTransactions(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []*types.UnverifiedTransaction
+	for _, utx := range txs {
+		var tx types.Transaction
+		if err = cbor.Unmarshal(utx.Body, &tx); err != nil {
+			// Skip malformed transactions.
+			continue
+		}
+
+		for _, si := range tx.AuthInfo.SignerInfo {
+			addr, err := si.AddressSpec.Address()
+			if err != nil || !addr.Equal(address) {
+				continue
+			}
+			filtered = append(filtered, utx)
+			break
+		}
+	}
+	return filtered, nil
+}
+
 // Implements RuntimeClient.
 func (rc *runtimeClient) GetEvents(ctx context.Context, round uint64) ([]*coreClient.Event, error) {
 	return rc.cc.GetEvents(ctx, &coreClient.GetEventsRequest{
@@ -171,6 +601,71 @@ func (rc *runtimeClient) GetEvents(ctx context.Context, round uint64) ([]*coreCl
 	})
 }
 
+// Implements RuntimeClient.
+func (rc *runtimeClient) GetEventsRange(
+	ctx context.Context,
+	fromRound, toRound uint64,
+	decoders []EventDecoder,
+) (<-chan *RoundEvents, <-chan error) {
+	ch := make(chan *RoundEvents)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+		defer close(ch)
+
+		if fromRound > toRound {
+			errCh <- fmt.Errorf("invalid round range [%d, %d]", fromRound, toRound)
+			return
+		}
+
+		// Check the range against the latest round once so that missing rounds inside the range
+		// can only be pruned ones.
+		latest, err := rc.GetBlock(ctx, RoundLatest)
+		if err != nil {
+			errCh <- fmt.Errorf("failed to fetch latest block: %w", err)
+			return
+		}
+		if toRound > latest.Header.Round {
+			errCh <- &FutureRoundError{Round: toRound, LatestRound: latest.Header.Round}
+			return
+		}
+
+		for round := fromRound; ; round++ {
+			rawEvents, err := rc.GetEvents(ctx, round)
+			switch {
+			case err == nil:
+			case isNotFound(err):
+				errCh <- &RoundPrunedError{Round: round, Err: err}
+				return
+			default:
+				errCh <- fmt.Errorf("failed to fetch events for round %d: %w", round, err)
+				return
+			}
+
+			events, err := DecodeEvents(rawEvents, decoders)
+			if err != nil {
+				errCh <- fmt.Errorf("round %d: %w", round, err)
+				return
+			}
+
+			select {
+			case ch <- &RoundEvents{Round: round, Events: events}:
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+
+			// Avoid overflow in case toRound is the maximum round.
+			if round == toRound {
+				return
+			}
+		}
+	}()
+
+	return ch, errCh
+}
+
 // Implements RuntimeClient.
 func (rc *runtimeClient) Query(ctx context.Context, round uint64, method string, args, rsp interface{}) error {
 	raw, err := rc.cc.Query(ctx, &coreClient.QueryRequest{
@@ -180,7 +675,7 @@ func (rc *runtimeClient) Query(ctx context.Context, round uint64, method string,
 		Args:      cbor.Marshal(args),
 	})
 	if err != nil {
-		return err
+		return rc.checkActive(ctx, round, err)
 	}
 	if err = cbor.Unmarshal(raw.Data, rsp); err != nil {
 		return fmt.Errorf("failed to unmarshal response: %w", err)
@@ -189,10 +684,34 @@ func (rc *runtimeClient) Query(ctx context.Context, round uint64, method string,
 }
 
 // New creates a new runtime client for the specified runtime.
-func New(conn *grpc.ClientConn, runtimeID common.Namespace) RuntimeClient {
-	return &runtimeClient{
+//
+// All calls are made over the given gRPC connection. To reduce bandwidth for large queries and
+// transaction submissions, compression can be enabled when dialing the connection by passing
+// CompressionDialOption to grpc.Dial.
+func New(conn *grpc.ClientConn, runtimeID common.Namespace, opts ...Option) RuntimeClient {
+	rc := &runtimeClient{
 		cs:        consensus.NewConsensusClient(conn),
 		cc:        coreClient.NewRuntimeClient(conn),
 		runtimeID: runtimeID,
 	}
+	for _, opt := range opts {
+		opt(rc)
+	}
+	if rc.queryRetry != nil {
+		return NewRetryingClient(rc, *rc.queryRetry)
+	}
+	return rc
+}
+
+// Option is a runtime client option.
+type Option func(rc *runtimeClient)
+
+// WithExpectedChainContext configures the chain context that the node is expected to use for the
+// runtime. In case the chain context reported by the node differs (e.g., because the client is
+// pointed at the wrong network), GetInfo (and thus any signing helper) fails with
+// ErrChainContextMismatch instead of silently producing invalid signatures.
+func WithExpectedChainContext(cc signature.Context) Option {
+	return func(rc *runtimeClient) {
+		rc.expectedChainContext = cc
+	}
 }
@@ -0,0 +1,424 @@
+package client
+
+import (
+	"context"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/secp256k1"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// fakeClient is a RuntimeClient used in tests. Only the methods with a configured handler may be
+// called, calling any other method panics.
+type fakeClient struct {
+	RuntimeClient
+
+	query           func(ctx context.Context, round uint64, method string, args interface{}) (interface{}, error)
+	getBlock        func(ctx context.Context, round uint64) (*block.Block, error)
+	getTransactions func(ctx context.Context, round uint64) ([]*types.UnverifiedTransaction, error)
+	submitTx        func(ctx context.Context, tx *types.UnverifiedTransaction) (cbor.RawMessage, error)
+	getEvents       func(ctx context.Context, round uint64) ([]*coreClient.Event, error)
+	watchBlocks     func(ctx context.Context) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error)
+}
+
+// Implements RuntimeClient.
+func (fc *fakeClient) Query(ctx context.Context, round uint64, method string, args, rsp interface{}) error {
+	if fc.query == nil {
+		return fmt.Errorf("fake: query not supported")
+	}
+	result, err := fc.query(ctx, round, method, args)
+	if err != nil {
+		return err
+	}
+	return cbor.Unmarshal(cbor.Marshal(result), rsp)
+}
+
+// Implements RuntimeClient.
+func (fc *fakeClient) GetBlock(ctx context.Context, round uint64) (*block.Block, error) {
+	if fc.getBlock == nil {
+		return nil, fmt.Errorf("fake: get block not supported")
+	}
+	return fc.getBlock(ctx, round)
+}
+
+// Implements RuntimeClient.
+func (fc *fakeClient) GetTransactions(ctx context.Context, round uint64) ([]*types.UnverifiedTransaction, error) {
+	if fc.getTransactions == nil {
+		return nil, fmt.Errorf("fake: get transactions not supported")
+	}
+	return fc.getTransactions(ctx, round)
+}
+
+// Implements RuntimeClient.
+func (fc *fakeClient) SubmitTx(ctx context.Context, tx *types.UnverifiedTransaction) (cbor.RawMessage, error) {
+	if fc.submitTx == nil {
+		return nil, fmt.Errorf("fake: submit tx not supported")
+	}
+	return fc.submitTx(ctx, tx)
+}
+
+// Implements RuntimeClient.
+func (fc *fakeClient) GetEvents(ctx context.Context, round uint64) ([]*coreClient.Event, error) {
+	if fc.getEvents == nil {
+		return nil, fmt.Errorf("fake: get events not supported")
+	}
+	return fc.getEvents(ctx, round)
+}
+
+// Implements RuntimeClient.
+func (fc *fakeClient) WatchBlocks(ctx context.Context) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	if fc.watchBlocks == nil {
+		return nil, nil, fmt.Errorf("fake: watch blocks not supported")
+	}
+	return fc.watchBlocks(ctx)
+}
+
+// fakeCoreClient is an Oasis Core runtime client used in tests.
+type fakeCoreClient struct {
+	coreClient.RuntimeClient
+
+	genesisErr   error
+	genesisCalls int
+
+	blocks      chan *roothash.AnnotatedBlock
+	getBlockErr error
+	latestRound uint64
+	prunedBelow uint64
+	txs         [][]byte
+}
+
+// hasRound checks whether the given specific round is available. In case no latest round is
+// configured, all rounds are available.
+func (fc *fakeCoreClient) hasRound(round uint64) bool {
+	if fc.latestRound == 0 {
+		return true
+	}
+	return round >= fc.prunedBelow && round <= fc.latestRound
+}
+
+func (fc *fakeCoreClient) WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	return fc.blocks, nil, nil
+}
+
+func (fc *fakeCoreClient) GetBlock(ctx context.Context, request *coreClient.GetBlockRequest) (*block.Block, error) {
+	if fc.getBlockErr != nil {
+		return nil, fc.getBlockErr
+	}
+	if request.Round != RoundLatest && !fc.hasRound(request.Round) {
+		return nil, roothash.ErrNotFound
+	}
+	var blk block.Block
+	blk.Header.Round = request.Round
+	if request.Round == RoundLatest && fc.latestRound != 0 {
+		blk.Header.Round = fc.latestRound
+		blk.Header.Timestamp = uint64(time.Now().Unix())
+	}
+	return &blk, nil
+}
+
+func (fc *fakeCoreClient) GetTxs(ctx context.Context, request *coreClient.GetTxsRequest) ([][]byte, error) {
+	return fc.txs, nil
+}
+
+func (fc *fakeCoreClient) GetEvents(ctx context.Context, request *coreClient.GetEventsRequest) ([]*coreClient.Event, error) {
+	if !fc.hasRound(request.Round) {
+		return nil, coreClient.ErrNotFound
+	}
+	return nil, nil
+}
+
+func (fc *fakeCoreClient) GetGenesisBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error) {
+	fc.genesisCalls++
+	if fc.genesisErr != nil {
+		return nil, fc.genesisErr
+	}
+	return &block.Block{}, nil
+}
+
+func TestCheckActive(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	notFound := fmt.Errorf("query failed: %w", coreClient.ErrNotFound)
+
+	// Inactive runtime.
+	fc := &fakeCoreClient{genesisErr: coreClient.ErrNotFound}
+	rc := &runtimeClient{cc: fc}
+	err := rc.checkActive(ctx, RoundLatest, notFound)
+	require.True(errors.Is(err, ErrRuntimeNotActive), "error should match ErrRuntimeNotActive")
+	require.True(errors.Is(err, coreClient.ErrNotFound), "error should retain the cause chain")
+	var rnaErr *RuntimeNotActiveError
+	require.True(errors.As(err, &rnaErr))
+	require.Equal(notFound, rnaErr.Err)
+	require.Equal(1, fc.genesisCalls)
+
+	// Specific non-genesis rounds are not checked.
+	err = rc.checkActive(ctx, 42, notFound)
+	require.Equal(notFound, err)
+	require.Equal(1, fc.genesisCalls, "genesis block should not be fetched for specific rounds")
+
+	// Other errors are returned unchanged.
+	otherErr := fmt.Errorf("other error")
+	err = rc.checkActive(ctx, RoundLatest, otherErr)
+	require.Equal(otherErr, err)
+	require.Equal(1, fc.genesisCalls)
+
+	// Active runtime.
+	fc = &fakeCoreClient{}
+	rc = &runtimeClient{cc: fc}
+	err = rc.checkActive(ctx, 0, notFound)
+	require.Equal(notFound, err)
+	require.Equal(1, fc.genesisCalls)
+}
+
+func TestWatchBlocksAndFetchTransactions(t *testing.T) {
+	require := require.New(t)
+
+	newBlock := func(round uint64) *roothash.AnnotatedBlock {
+		var blk block.Block
+		blk.Header.Round = round
+		return &roothash.AnnotatedBlock{Block: &blk}
+	}
+
+	fc := &fakeCoreClient{
+		blocks: make(chan *roothash.AnnotatedBlock, 2),
+		txs:    [][]byte{cbor.Marshal(&types.UnverifiedTransaction{Body: []byte("tx")})},
+	}
+	rc := &runtimeClient{cc: fc}
+	ch, errCh, _, err := rc.WatchBlocksAndFetchTransactions(context.Background())
+	require.NoError(err)
+
+	fc.blocks <- newBlock(1)
+	bt := <-ch
+	require.EqualValues(1, bt.Block.Block.Header.Round)
+	require.Len(bt.Transactions, 1)
+	require.EqualValues([]byte("tx"), bt.Transactions[0].Body)
+
+	// Failing to fetch transactions should be reported over the error channel.
+	fc.getBlockErr = fmt.Errorf("get block failed")
+	fc.blocks <- newBlock(2)
+	err = <-errCh
+	require.Error(err)
+	require.True(errors.Is(err, fc.getBlockErr), "error should retain the cause chain")
+	_, ok := <-ch
+	require.False(ok, "block channel should be closed after an error")
+}
+
+func TestGetEventsRange(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	fc := &fakeCoreClient{latestRound: 10, prunedBelow: 5}
+	rc := &runtimeClient{cc: fc}
+
+	collect := func(fromRound, toRound uint64) ([]uint64, error) {
+		ch, errCh := rc.GetEventsRange(ctx, fromRound, toRound, nil)
+		var rounds []uint64
+		for ev := range ch {
+			rounds = append(rounds, ev.Round)
+		}
+		return rounds, <-errCh
+	}
+
+	rounds, err := collect(5, 10)
+	require.NoError(err)
+	require.Equal([]uint64{5, 6, 7, 8, 9, 10}, rounds)
+
+	_, err = collect(8, 12)
+	var frErr *FutureRoundError
+	require.True(errors.As(err, &frErr), "rounds beyond the latest round should not be reported as pruned")
+	require.EqualValues(12, frErr.Round)
+	require.EqualValues(10, frErr.LatestRound)
+
+	rounds, err = collect(3, 6)
+	require.Empty(rounds)
+	var rpErr *RoundPrunedError
+	require.True(errors.As(err, &rpErr), "rounds below the last retained round should be reported as pruned")
+	require.EqualValues(3, rpErr.Round)
+	require.True(errors.Is(err, coreClient.ErrNotFound), "error should retain the cause chain")
+}
+
+func TestResolveRound(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	fc := &fakeCoreClient{latestRound: 10, prunedBelow: 5}
+	rc := &runtimeClient{cc: fc}
+
+	round, err := rc.resolveRound(ctx, SelectRound(7))
+	require.NoError(err)
+	require.EqualValues(7, round)
+
+	_, err = rc.resolveRound(ctx, SelectRound(11))
+	var frErr *FutureRoundError
+	require.True(errors.As(err, &frErr), "rounds beyond the latest round should not be reported as pruned")
+	require.EqualValues(11, frErr.Round)
+	require.EqualValues(10, frErr.LatestRound)
+
+	_, err = rc.resolveRound(ctx, SelectRound(4))
+	var rpErr *RoundPrunedError
+	require.True(errors.As(err, &rpErr), "rounds below the last retained round should be reported as pruned")
+	require.EqualValues(4, rpErr.Round)
+	require.True(errors.Is(err, roothash.ErrNotFound), "error should retain the cause chain")
+}
+
+func TestHealthCheckRoundStalled(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	fc := &fakeCoreClient{latestRound: 10}
+	rc := &runtimeClient{
+		cc:                 fc,
+		runtimeInfo:        &types.RuntimeInfo{},
+		runtimeInfoFetched: time.Now(),
+	}
+
+	status, err := rc.HealthCheck(ctx, "")
+	require.NoError(err)
+	require.False(status.RoundStalled, "first observation should not be considered stalled")
+	require.True(status.IsHealthy())
+
+	// Pretend the round was first observed long ago.
+	rc.healthRoundSeen = rc.healthRoundSeen.Add(-2 * HealthCheckMaxLag)
+	status, err = rc.HealthCheck(ctx, "")
+	require.NoError(err)
+	require.True(status.RoundStalled, "unchanged round should be considered stalled")
+	require.False(status.IsHealthy())
+
+	fc.latestRound = 11
+	status, err = rc.HealthCheck(ctx, "")
+	require.NoError(err)
+	require.False(status.RoundStalled, "advanced round should not be considered stalled")
+	require.True(status.IsHealthy())
+}
+
+func TestGetTransactionsBySigner(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	chainCtx := signature.DeriveChainContext(runtimeID, "0000000000000000000000000000000000000000000000000000000000000001")
+	alice := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: alice"))
+	bob := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: bob"))
+	charlieKey := sha512.Sum512_256([]byte("oasis-runtime-sdk/test-keys: charlie"))
+	charlie := secp256k1.NewSigner(charlieKey[:])
+
+	signTx := func(signer signature.Signer) []byte {
+		tx := types.NewTransaction(nil, "test.Foo", nil)
+		tx.AppendAuthSignature(signer.Public(), 0)
+		ts := tx.PrepareForSigning()
+		require.NoError(ts.AppendSign(chainCtx, signer))
+		return cbor.Marshal(ts.UnverifiedTransaction())
+	}
+
+	fc := &fakeCoreClient{
+		txs: [][]byte{signTx(alice), signTx(bob), signTx(alice), signTx(charlie), []byte("malformed")},
+	}
+	rc := &runtimeClient{cc: fc}
+	ctx := context.Background()
+
+	txs, err := rc.GetTransactionsBySigner(ctx, 1, types.NewAddress(alice.Public()))
+	require.NoError(err)
+	require.Len(txs, 2)
+	require.EqualValues(fc.txs[0], cbor.Marshal(txs[0]))
+	require.EqualValues(fc.txs[2], cbor.Marshal(txs[1]))
+
+	txs, err = rc.GetTransactionsBySigner(ctx, 1, types.NewAddress(charlie.Public()))
+	require.NoError(err)
+	require.Len(txs, 1)
+	require.EqualValues(fc.txs[3], cbor.Marshal(txs[0]))
+}
+
+// newTestConn starts an in-memory gRPC server with the given options and returns a connection
+// to it.
+func newTestConn(t *testing.T, opts ...grpc.ServerOption) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(opts...)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(&cmnGrpc.CBORCodec{})),
+	)
+	require.NoError(t, err, "grpc.Dial")
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+func TestStreamingError(t *testing.T) {
+	require := require.New(t)
+
+	err := streamingError(status.Error(codes.Unimplemented, "unknown service"))
+	require.True(errors.Is(err, ErrStreamingUnsupported), "unimplemented should match ErrStreamingUnsupported")
+
+	otherErr := status.Error(codes.Unavailable, "connection refused")
+	require.Equal(otherErr, streamingError(otherErr), "other errors should be returned unchanged")
+}
+
+func TestWatchBlocks(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	_ = runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+
+	conn := newTestConn(t, grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+		var id common.Namespace
+		if err := stream.RecvMsg(&id); err != nil {
+			return err
+		}
+		if id != runtimeID {
+			return fmt.Errorf("unexpected runtime ID: %s", id)
+		}
+		for round := uint64(1); round <= 2; round++ {
+			var blk roothash.AnnotatedBlock
+			blk.Block = &block.Block{}
+			blk.Block.Header.Round = round
+			if err := stream.SendMsg(&blk); err != nil {
+				return err
+			}
+		}
+		<-stream.Context().Done()
+		return nil
+	}))
+	rc := New(conn, runtimeID)
+
+	ch, sub, err := rc.WatchBlocks(context.Background())
+	require.NoError(err)
+	for round := uint64(1); round <= 2; round++ {
+		blk := <-ch
+		require.EqualValues(round, blk.Block.Header.Round)
+	}
+
+	sub.Close()
+	for range ch {
+	}
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+// DefaultCompressionThreshold is the default payload size (in bytes) above which requests are
+// compressed when compression is enabled via CompressionDialOption.
+const DefaultCompressionThreshold = 64 * 1024
+
+// CompressionDialOption returns a gRPC dial option that enables compressing transaction
+// submissions and queries whose payload is at least threshold bytes large using the given gRPC
+// compressor (e.g., gzip.Name). Smaller payloads are sent uncompressed to avoid the CPU cost.
+//
+// The option must be passed to grpc.Dial when dialing the connection used by the runtime client.
+// The compressor must be registered with gRPC (e.g., by importing the
+// google.golang.org/grpc/encoding/gzip package) and the node must support it, otherwise the
+// affected calls will fail.
+func CompressionDialOption(compressor string, threshold int) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(compressionInterceptor(compressor, threshold))
+}
+
+func compressionInterceptor(compressor string, threshold int) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		size, ok := compressiblePayloadSize(req)
+		if ok && size >= threshold {
+			if encoding.GetCompressor(compressor) == nil {
+				return fmt.Errorf("compressor '%s' not registered", compressor)
+			}
+			opts = append(opts, grpc.UseCompressor(compressor))
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// compressiblePayloadSize returns the payload size of the given runtime client request in case
+// it is a transaction submission or a query.
+func compressiblePayloadSize(req interface{}) (int, bool) {
+	switch r := req.(type) {
+	case *coreClient.SubmitTxRequest:
+		return len(r.Data), true
+	case *coreClient.QueryRequest:
+		return len(r.Args), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+func TestCompressionInterceptor(t *testing.T) {
+	require := require.New(t)
+
+	var callOpts []grpc.CallOption
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		callOpts = opts
+		return nil
+	}
+	invoke := func(interceptor grpc.UnaryClientInterceptor, req interface{}) error {
+		callOpts = nil
+		return interceptor(context.Background(), "", req, nil, nil, invoker)
+	}
+
+	interceptor := compressionInterceptor(gzip.Name, DefaultCompressionThreshold)
+	err := invoke(interceptor, &coreClient.QueryRequest{Args: make([]byte, DefaultCompressionThreshold-1)})
+	require.NoError(err)
+	require.Empty(callOpts, "payloads below the threshold should not be compressed")
+
+	err = invoke(interceptor, &coreClient.QueryRequest{Args: make([]byte, DefaultCompressionThreshold)})
+	require.NoError(err)
+	require.Len(callOpts, 1, "payloads at the threshold should be compressed")
+
+	err = invoke(interceptor, &coreClient.SubmitTxRequest{Data: make([]byte, DefaultCompressionThreshold)})
+	require.NoError(err)
+	require.Len(callOpts, 1, "large transaction submissions should be compressed")
+
+	err = invoke(compressionInterceptor(gzip.Name, 0), &coreClient.GetBlockRequest{})
+	require.NoError(err)
+	require.Empty(callOpts, "other requests should not be compressed")
+
+	err = invoke(compressionInterceptor("unknown", 0), &coreClient.QueryRequest{})
+	require.Error(err, "unregistered compressors should be rejected")
+}
@@ -0,0 +1,286 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// crossLayerResubscribeInterval is the interval after which a closed or failed subscription is
+// re-established.
+const crossLayerResubscribeInterval = 1 * time.Second
+
+// CrossLayerEvent is an event observed by the CrossLayerClient. Exactly one of the fields is
+// set.
+type CrossLayerEvent struct {
+	// Runtime are the decoded runtime events emitted in a given runtime round.
+	Runtime *RoundEvents
+	// Staking is a consensus layer staking event.
+	Staking *staking.Event
+	// Error is an error encountered while watching events. The affected subscription is
+	// re-established and any missed rounds or heights are backfilled.
+	Error error
+}
+
+// CrossLayerClient bundles a runtime client together with a consensus layer staking client
+// sharing the same connection, for tools that need to reconcile activity across both layers.
+type CrossLayerClient struct {
+	// Runtime is the runtime client.
+	Runtime RuntimeClient
+	// Staking is the consensus layer staking client.
+	Staking staking.Backend
+}
+
+// NewCrossLayerClient creates a new cross-layer client for the specified runtime.
+func NewCrossLayerClient(conn *grpc.ClientConn, runtimeID common.Namespace) *CrossLayerClient {
+	return &CrossLayerClient{
+		Runtime: New(conn, runtimeID),
+		Staking: staking.NewStakingClient(conn),
+	}
+}
+
+// WatchEvents subscribes to both runtime events (decoded using the given decoders) and consensus
+// layer staking events and delivers them over a single channel.
+//
+// In case any of the underlying subscriptions fails, the error is delivered over the channel and
+// the subscription is re-established. Any runtime rounds or consensus heights that were missed in
+// the meantime are backfilled so that no events are lost. The returned channel is closed once the
+// context is canceled.
+func (cl *CrossLayerClient) WatchEvents(ctx context.Context, decoders []EventDecoder) <-chan *CrossLayerEvent {
+	ch := make(chan *CrossLayerEvent)
+	done := make(chan struct{}, 2)
+
+	go func() {
+		defer func() { done <- struct{}{} }()
+		cl.watchRuntimeEvents(ctx, decoders, ch)
+	}()
+	go func() {
+		defer func() { done <- struct{}{} }()
+		cl.watchStakingEvents(ctx, ch)
+	}()
+	go func() {
+		<-done
+		<-done
+		close(ch)
+	}()
+
+	return ch
+}
+
+// sendCrossLayerEvent delivers the given event and returns false in case the context has been
+// canceled before the event could be delivered.
+func sendCrossLayerEvent(ctx context.Context, ch chan<- *CrossLayerEvent, ev *CrossLayerEvent) bool {
+	select {
+	case ch <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// waitResubscribe waits for the resubscribe interval and returns false in case the context has
+// been canceled in the meantime.
+func waitResubscribe(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(crossLayerResubscribeInterval):
+		return true
+	}
+}
+
+// runtimeWatcher keeps track of the next runtime round to be forwarded by the CrossLayerClient.
+type runtimeWatcher struct {
+	cl       *CrossLayerClient
+	decoders []EventDecoder
+	ch       chan<- *CrossLayerEvent
+
+	nextRound uint64
+	started   bool
+}
+
+func (cl *CrossLayerClient) watchRuntimeEvents(ctx context.Context, decoders []EventDecoder, ch chan<- *CrossLayerEvent) {
+	w := &runtimeWatcher{cl: cl, decoders: decoders, ch: ch}
+	for {
+		blkCh, blkSub, err := cl.Runtime.WatchBlocks(ctx)
+		switch err {
+		case nil:
+			err = w.forward(ctx, blkCh)
+			blkSub.Close()
+		default:
+			err = fmt.Errorf("failed to watch runtime blocks: %w", err)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil && !sendCrossLayerEvent(ctx, ch, &CrossLayerEvent{Error: err}) {
+			return
+		}
+		if !waitResubscribe(ctx) {
+			return
+		}
+	}
+}
+
+// forward forwards events for all blocks received over blkCh, backfilling any rounds that were
+// missed since the last forwarded round. Blocks for rounds that were already forwarded are
+// ignored.
+func (w *runtimeWatcher) forward(ctx context.Context, blkCh <-chan *roothash.AnnotatedBlock) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case blk, ok := <-blkCh:
+			if !ok {
+				return fmt.Errorf("runtime block subscription closed")
+			}
+
+			round := blk.Block.Header.Round
+			if !w.started {
+				w.nextRound = round
+				w.started = true
+			}
+			for w.nextRound <= round {
+				if err := w.forwardRound(ctx, w.nextRound); err != nil {
+					return err
+				}
+				if ctx.Err() != nil {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// forwardRound forwards the events emitted in the given round.
+func (w *runtimeWatcher) forwardRound(ctx context.Context, round uint64) error {
+	rawEvents, err := w.cl.Runtime.GetEvents(ctx, round)
+	if err != nil {
+		return fmt.Errorf("failed to get events for round %d: %w", round, err)
+	}
+	events, err := DecodeEvents(rawEvents, w.decoders)
+	if err != nil {
+		return fmt.Errorf("failed to decode events for round %d: %w", round, err)
+	}
+	if len(events) > 0 {
+		ev := &CrossLayerEvent{Runtime: &RoundEvents{Round: round, Events: events}}
+		if !sendCrossLayerEvent(ctx, w.ch, ev) {
+			return nil
+		}
+	}
+	w.nextRound = round + 1
+	return nil
+}
+
+// stakingWatcher keeps track of the last consensus height forwarded by the CrossLayerClient.
+type stakingWatcher struct {
+	cl *CrossLayerClient
+	ch chan<- *CrossLayerEvent
+
+	// lastHeight is the height of the last forwarded staking event and lastCount is the number
+	// of events forwarded at that height.
+	lastHeight int64
+	lastCount  int
+	started    bool
+	// resumed is set while the subscription has been re-established and no event from a new
+	// height has been received over it yet.
+	resumed bool
+}
+
+func (cl *CrossLayerClient) watchStakingEvents(ctx context.Context, ch chan<- *CrossLayerEvent) {
+	w := &stakingWatcher{cl: cl, ch: ch}
+	for {
+		evCh, evSub, err := cl.Staking.WatchEvents(ctx)
+		switch err {
+		case nil:
+			w.resumed = w.started
+			err = w.forward(ctx, evCh)
+			evSub.Close()
+		default:
+			err = fmt.Errorf("failed to watch staking events: %w", err)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil && !sendCrossLayerEvent(ctx, ch, &CrossLayerEvent{Error: err}) {
+			return
+		}
+		if !waitResubscribe(ctx) {
+			return
+		}
+	}
+}
+
+// forward forwards all staking events received over evCh. After the subscription has been
+// re-established, events at heights that were (partially) forwarded before are ignored and the
+// missed events are backfilled once an event from a new height is received.
+func (w *stakingWatcher) forward(ctx context.Context, evCh <-chan *staking.Event) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-evCh:
+			if !ok {
+				return fmt.Errorf("staking event subscription closed")
+			}
+
+			if w.resumed {
+				if ev.Height <= w.lastHeight {
+					continue
+				}
+				if err := w.backfill(ctx, ev.Height); err != nil {
+					return err
+				}
+				w.resumed = false
+			}
+			if !w.send(ctx, ev) {
+				return nil
+			}
+		}
+	}
+}
+
+// backfill forwards the staking events emitted at heights from the last forwarded height up to
+// (but excluding) the given height that have not been forwarded yet.
+func (w *stakingWatcher) backfill(ctx context.Context, height int64) error {
+	for h := w.lastHeight; h < height; h++ {
+		events, err := w.cl.Staking.GetEvents(ctx, h)
+		if err != nil {
+			return fmt.Errorf("failed to get staking events for height %d: %w", h, err)
+		}
+		if h == w.lastHeight {
+			if w.lastCount >= len(events) {
+				continue
+			}
+			events = events[w.lastCount:]
+		}
+		for _, ev := range events {
+			if !w.send(ctx, ev) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// send forwards the given staking event and updates the last forwarded position.
+func (w *stakingWatcher) send(ctx context.Context, ev *staking.Event) bool {
+	if !sendCrossLayerEvent(ctx, w.ch, &CrossLayerEvent{Staking: ev}) {
+		return false
+	}
+	switch {
+	case w.started && ev.Height == w.lastHeight:
+		w.lastCount++
+	default:
+		w.lastHeight = ev.Height
+		w.lastCount = 1
+		w.started = true
+	}
+	return true
+}
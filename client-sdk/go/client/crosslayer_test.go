@@ -0,0 +1,172 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// fakeSubscription is a subscription used in tests.
+type fakeSubscription struct{}
+
+func (fakeSubscription) Close() {}
+
+// fakeStakingBackend is a staking backend used in tests. Subscriptions are served from the
+// configured channels in order, after which subscribing fails.
+type fakeStakingBackend struct {
+	staking.Backend
+
+	subs   []chan *staking.Event
+	events map[int64][]*staking.Event
+}
+
+func (fb *fakeStakingBackend) WatchEvents(ctx context.Context) (<-chan *staking.Event, pubsub.ClosableSubscription, error) {
+	if len(fb.subs) == 0 {
+		return nil, nil, fmt.Errorf("fake: no more subscriptions")
+	}
+	ch := fb.subs[0]
+	fb.subs = fb.subs[1:]
+	return ch, fakeSubscription{}, nil
+}
+
+func (fb *fakeStakingBackend) GetEvents(ctx context.Context, height int64) ([]*staking.Event, error) {
+	return fb.events[height], nil
+}
+
+// roundCodeDecoder decodes every event into its code.
+type roundCodeDecoder struct{}
+
+func (roundCodeDecoder) DecodeEvent(ev *Event) ([]DecodedEvent, error) {
+	return []DecodedEvent{ev.Code}, nil
+}
+
+func TestCrossLayerRuntimeBackfill(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	newBlock := func(round uint64) *roothash.AnnotatedBlock {
+		var blk block.Block
+		blk.Header.Round = round
+		return &roothash.AnnotatedBlock{Block: &blk}
+	}
+
+	// The first subscription delivers rounds 1 and 2 and is then closed, the following ones only
+	// deliver round 5.
+	sub1 := make(chan *roothash.AnnotatedBlock, 2)
+	sub1 <- newBlock(1)
+	sub1 <- newBlock(2)
+	close(sub1)
+	sub2 := make(chan *roothash.AnnotatedBlock, 1)
+	sub2 <- newBlock(5)
+	sub3 := make(chan *roothash.AnnotatedBlock, 1)
+	sub3 <- newBlock(5)
+	subs := []chan *roothash.AnnotatedBlock{sub1, sub2, sub3}
+
+	failRound := uint64(3)
+	rc := &fakeClient{
+		watchBlocks: func(ctx context.Context) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+			if len(subs) == 0 {
+				return make(chan *roothash.AnnotatedBlock), fakeSubscription{}, nil
+			}
+			ch := subs[0]
+			subs = subs[1:]
+			return ch, fakeSubscription{}, nil
+		},
+		getEvents: func(ctx context.Context, round uint64) ([]*coreClient.Event, error) {
+			if round == failRound {
+				failRound = 0
+				return nil, fmt.Errorf("get events failed")
+			}
+			key := make([]byte, 8)
+			copy(key, "test")
+			binary.BigEndian.PutUint32(key[4:], uint32(round))
+			return []*coreClient.Event{{Key: key}}, nil
+		},
+	}
+	sb := &fakeStakingBackend{subs: []chan *staking.Event{make(chan *staking.Event)}}
+	cl := &CrossLayerClient{Runtime: rc, Staking: sb}
+
+	ch := cl.WatchEvents(ctx, []EventDecoder{roundCodeDecoder{}})
+	nextRuntimeEvent := func() *CrossLayerEvent {
+		ev := <-ch
+		require.Nil(ev.Staking, "no staking events should be emitted")
+		return ev
+	}
+
+	for _, round := range []uint64{1, 2} {
+		ev := nextRuntimeEvent()
+		require.NoError(ev.Error)
+		require.EqualValues(round, ev.Runtime.Round)
+	}
+	require.Error(nextRuntimeEvent().Error, "closed subscription should be reported")
+
+	// Fetching events for round 3 fails, which should be reported and the round retried.
+	require.Error(nextRuntimeEvent().Error, "failure to fetch events should be reported")
+	for _, round := range []uint64{3, 4, 5} {
+		ev := nextRuntimeEvent()
+		require.NoError(ev.Error)
+		require.EqualValues(round, ev.Runtime.Round, "missed rounds should be backfilled")
+		require.EqualValues([]DecodedEvent{uint32(round)}, ev.Runtime.Events)
+	}
+}
+
+func TestCrossLayerStakingBackfill(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := map[int64][]*staking.Event{
+		10: {{Height: 10}, {Height: 10}, {Height: 10}},
+		11: {{Height: 11}},
+		12: {{Height: 12}},
+	}
+
+	// The first subscription is closed after delivering two out of three events at height 10, the
+	// second one starts with the last event at height 10 followed by height 12.
+	sub1 := make(chan *staking.Event, 2)
+	sub1 <- events[10][0]
+	sub1 <- events[10][1]
+	close(sub1)
+	sub2 := make(chan *staking.Event, 2)
+	sub2 <- events[10][2]
+	sub2 <- events[12][0]
+
+	rc := &fakeClient{
+		watchBlocks: func(ctx context.Context) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+			return make(chan *roothash.AnnotatedBlock), fakeSubscription{}, nil
+		},
+	}
+	sb := &fakeStakingBackend{
+		subs:   []chan *staking.Event{sub1, sub2, make(chan *staking.Event)},
+		events: events,
+	}
+	cl := &CrossLayerClient{Runtime: rc, Staking: sb}
+
+	ch := cl.WatchEvents(ctx, nil)
+	nextStakingEvent := func() *CrossLayerEvent {
+		ev := <-ch
+		require.Nil(ev.Runtime, "no runtime events should be emitted")
+		return ev
+	}
+
+	require.Equal(events[10][0], nextStakingEvent().Staking)
+	require.Equal(events[10][1], nextStakingEvent().Staking)
+	require.Error(nextStakingEvent().Error, "closed subscription should be reported")
+	for _, expected := range []*staking.Event{events[10][2], events[11][0], events[12][0]} {
+		ev := nextStakingEvent()
+		require.NoError(ev.Error)
+		require.True(expected == ev.Staking, "missed events should be backfilled exactly once")
+	}
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+// EventDecoder is an event decoder interface.
+type EventDecoder interface {
+	// DecodeEvent decodes an event. In case the event is not relevant, `nil, nil` should be
+	// returned.
+	DecodeEvent(*Event) ([]DecodedEvent, error)
+}
+
+// DecodedEvent is a decoded event.
+type DecodedEvent interface{}
+
+// RoundEvents are the decoded events emitted in a given round.
+type RoundEvents struct {
+	// Round is the round in which the events were emitted.
+	Round uint64
+	// Events are the decoded events.
+	Events []DecodedEvent
+}
+
+// RoundPrunedError is the error returned when the requested round is no longer available as it
+// has been pruned.
+type RoundPrunedError struct {
+	// Round is the pruned round.
+	Round uint64
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the string representation of the error.
+func (e *RoundPrunedError) Error() string {
+	return fmt.Sprintf("round %d has been pruned: %s", e.Round, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RoundPrunedError) Unwrap() error {
+	return e.Err
+}
+
+// FutureRoundError is the error returned when the requested round is not yet available as it is
+// beyond the latest round.
+type FutureRoundError struct {
+	// Round is the requested round.
+	Round uint64
+	// LatestRound is the latest round at the time of the request.
+	LatestRound uint64
+	// Err is the underlying error (if any).
+	Err error
+}
+
+// Error returns the string representation of the error.
+func (e *FutureRoundError) Error() string {
+	msg := fmt.Sprintf("round %d is beyond the latest round %d", e.Round, e.LatestRound)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *FutureRoundError) Unwrap() error {
+	return e.Err
+}
+
+// isNotFound checks whether the given error indicates that the requested data was not found.
+func isNotFound(err error) bool {
+	return errors.Is(err, coreClient.ErrNotFound) || errors.Is(err, roothash.ErrNotFound)
+}
+
+// newEventFromCore converts a runtime transaction tag into an event.
+func newEventFromCore(ev *coreClient.Event) (*Event, error) {
+	if len(ev.Key) < 4 {
+		return nil, fmt.Errorf("malformed event key")
+	}
+	return &Event{
+		Module: string(ev.Key[:len(ev.Key)-4]),
+		Code:   binary.BigEndian.Uint32(ev.Key[len(ev.Key)-4:]),
+		TxHash: ev.TxHash,
+		Value:  ev.Value,
+	}, nil
+}
+
+// DecodeEvents decodes the given raw runtime events using the given decoders.
+//
+// Events that were not emitted by runtime SDK modules are skipped.
+func DecodeEvents(rawEvents []*coreClient.Event, decoders []EventDecoder) ([]DecodedEvent, error) {
+	var events []DecodedEvent
+	for _, rawEv := range rawEvents {
+		ev, err := newEventFromCore(rawEv)
+		if err != nil {
+			// Skip events that were not emitted by an SDK module.
+			continue
+		}
+		for _, decoder := range decoders {
+			decoded, err := decoder.DecodeEvent(ev)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode event %s/%d: %w", ev.Module, ev.Code, err)
+			}
+			events = append(events, decoded...)
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+)
+
+// HealthCheckMaxLag is the maximum age of the latest runtime block for the node to still be
+// considered healthy.
+const HealthCheckMaxLag = 1 * time.Minute
+
+// HealthStatus is the result of a node health check.
+type HealthStatus struct {
+	// RuntimeIDMatches is true iff the node is serving the runtime the client was created for.
+	RuntimeIDMatches bool
+	// ChainContext is the chain domain separation context as reported by the node.
+	ChainContext signature.Context
+	// ChainContextMatches is true iff the chain context matches the expected chain context. In
+	// case no chain context was expected, this is always true.
+	ChainContextMatches bool
+	// LatestRound is the latest runtime round known to the node.
+	LatestRound uint64
+	// LatestRoundTime is the timestamp of the latest runtime round known to the node.
+	LatestRoundTime time.Time
+	// Lag is the age of the latest runtime round.
+	Lag time.Duration
+	// RoundStalled is true iff the latest round has not advanced since it was first observed by
+	// a health check of this client more than HealthCheckMaxLag ago. Detecting a stalled round
+	// therefore requires repeated health checks.
+	RoundStalled bool
+}
+
+// IsHealthy returns true iff all of the health checks passed.
+func (hs *HealthStatus) IsHealthy() bool {
+	return hs.RuntimeIDMatches && hs.ChainContextMatches && hs.Lag <= HealthCheckMaxLag && !hs.RoundStalled
+}
+
+// String returns a string representation of the health status.
+func (hs *HealthStatus) String() string {
+	return fmt.Sprintf(
+		"healthy: %t runtime_id_matches: %t chain_context_matches: %t latest_round: %d lag: %s round_stalled: %t",
+		hs.IsHealthy(), hs.RuntimeIDMatches, hs.ChainContextMatches, hs.LatestRound, hs.Lag, hs.RoundStalled,
+	)
+}
+
+// Implements RuntimeClient.
+func (rc *runtimeClient) HealthCheck(ctx context.Context, expectedChainContext signature.Context) (*HealthStatus, error) {
+	var status HealthStatus
+
+	genesis, err := rc.GetGenesisBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch genesis block: %w", err)
+	}
+	status.RuntimeIDMatches = genesis.Header.Namespace == rc.runtimeID
+
+	info, err := rc.GetInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch runtime info: %w", err)
+	}
+	status.ChainContext = info.ChainContext
+	status.ChainContextMatches = expectedChainContext == "" || info.ChainContext == expectedChainContext
+
+	blk, err := rc.GetBlock(ctx, RoundLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+	status.LatestRound = blk.Header.Round
+	status.LatestRoundTime = time.Unix(int64(blk.Header.Timestamp), 0)
+	status.Lag = time.Since(status.LatestRoundTime)
+	status.RoundStalled = rc.observeHealthRound(status.LatestRound)
+
+	return &status, nil
+}
+
+// observeHealthRound records the latest round observed by a health check and returns true iff
+// the round has not advanced within HealthCheckMaxLag.
+func (rc *runtimeClient) observeHealthRound(round uint64) bool {
+	rc.healthLock.Lock()
+	defer rc.healthLock.Unlock()
+
+	if rc.healthRoundSeen.IsZero() || round > rc.healthRound {
+		rc.healthRound = round
+		rc.healthRoundSeen = time.Now()
+	}
+	return time.Since(rc.healthRoundSeen) > HealthCheckMaxLag
+}
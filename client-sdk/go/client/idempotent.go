@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// TxAlreadyIncludedError is the error returned by the IdempotentSubmitter when a resubmitted
+// transaction has already been included in a block and was therefore not submitted again.
+type TxAlreadyIncludedError struct {
+	// TxHash is the hash of the transaction.
+	TxHash hash.Hash
+	// Round is the round in which the transaction was included.
+	Round uint64
+}
+
+// Error returns the string representation of the error.
+func (e *TxAlreadyIncludedError) Error() string {
+	return fmt.Sprintf("transaction %s already included in round %d", e.TxHash, e.Round)
+}
+
+// DefaultIdempotentRoundWindow is the default number of rounds after the first submission
+// during which a transaction is tracked by the IdempotentSubmitter.
+const DefaultIdempotentRoundWindow = 100
+
+// IdempotentSubmitter is an opt-in layer on top of a RuntimeClient which makes it safe to retry
+// submission of non-idempotent transactions (e.g., after a timeout).
+//
+// It records the hash of every submitted transaction together with the latest round at the
+// time of the first submission. When identical transaction bytes are submitted again, the
+// blocks since the first submission are checked for inclusion first and the transaction is only
+// resubmitted in case it has not been included yet.
+//
+// Transactions are no longer tracked once they are known to have been included or once the
+// configured round window has passed since their first submission. Only the blocks within the
+// round window are checked for inclusion.
+type IdempotentSubmitter struct {
+	l sync.Mutex
+
+	rc          RuntimeClient
+	roundWindow uint64
+
+	// submitted maps transaction hashes to the latest round at the time of first submission.
+	submitted map[hash.Hash]uint64
+}
+
+// FindTransactionRound returns the first round in the given (inclusive) range that includes the
+// transaction with the given hash.
+func FindTransactionRound(ctx context.Context, rc RuntimeClient, txHash hash.Hash, fromRound, toRound uint64) (uint64, bool, error) {
+	for round := fromRound; round <= toRound; round++ {
+		txs, err := rc.GetTransactions(ctx, round)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to fetch transactions for round %d: %w", round, err)
+		}
+		for _, tx := range txs {
+			if hash.NewFromBytes(cbor.Marshal(tx)) == txHash {
+				return round, true, nil
+			}
+		}
+	}
+	return 0, false, nil
+}
+
+// pruneLocked removes all tracked transactions whose round window has passed.
+func (is *IdempotentSubmitter) pruneLocked(latestRound uint64) {
+	for txHash, round := range is.submitted {
+		if round+is.roundWindow < latestRound {
+			delete(is.submitted, txHash)
+		}
+	}
+}
+
+// SubmitTx submits a transaction to the runtime transaction scheduler and waits for transaction
+// execution results.
+//
+// In case the identical transaction has already been submitted via this submitter and has been
+// included in a block in the meantime, it is not submitted again and a *TxAlreadyIncludedError
+// is returned instead.
+func (is *IdempotentSubmitter) SubmitTx(ctx context.Context, tx *types.UnverifiedTransaction) (cbor.RawMessage, error) {
+	txHash := hash.NewFromBytes(cbor.Marshal(tx))
+
+	blk, err := is.rc.GetBlock(ctx, RoundLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+	latestRound := blk.Header.Round
+
+	is.l.Lock()
+	is.pruneLocked(latestRound)
+	fromRound, retry := is.submitted[txHash]
+	if !retry {
+		is.submitted[txHash] = latestRound
+	}
+	toRound := fromRound + is.roundWindow
+	is.l.Unlock()
+
+	if retry {
+		// This is a retry, check whether the transaction has already been included.
+		if latestRound < toRound {
+			toRound = latestRound
+		}
+		round, included, err := FindTransactionRound(ctx, is.rc, txHash, fromRound, toRound)
+		if err != nil {
+			return nil, err
+		}
+		if included {
+			is.forget(txHash)
+			return nil, &TxAlreadyIncludedError{TxHash: txHash, Round: round}
+		}
+	}
+
+	result, err := is.rc.SubmitTx(ctx, tx)
+	var failed *types.FailedCallResult
+	if err == nil || errors.As(err, &failed) {
+		// The transaction has been included (even if it failed) so it no longer needs tracking.
+		is.forget(txHash)
+	}
+	return result, err
+}
+
+func (is *IdempotentSubmitter) forget(txHash hash.Hash) {
+	is.l.Lock()
+	defer is.l.Unlock()
+
+	delete(is.submitted, txHash)
+}
+
+// Forget removes the given transaction from the set of tracked submissions.
+func (is *IdempotentSubmitter) Forget(tx *types.UnverifiedTransaction) {
+	is.forget(hash.NewFromBytes(cbor.Marshal(tx)))
+}
+
+// SetRoundWindow configures the number of rounds after the first submission during which a
+// transaction is tracked.
+func (is *IdempotentSubmitter) SetRoundWindow(window uint64) *IdempotentSubmitter {
+	is.l.Lock()
+	defer is.l.Unlock()
+
+	is.roundWindow = window
+	return is
+}
+
+// NewIdempotentSubmitter creates a new idempotent submitter for the given runtime client.
+func NewIdempotentSubmitter(rc RuntimeClient) *IdempotentSubmitter {
+	return &IdempotentSubmitter{
+		rc:          rc,
+		roundWindow: DefaultIdempotentRoundWindow,
+		submitted:   make(map[hash.Hash]uint64),
+	}
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestIdempotentSubmitter(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	var (
+		latestRound  uint64 = 10
+		included            = make(map[uint64][]*types.UnverifiedTransaction)
+		scanned      []uint64
+		submits      int
+		submitResult error
+	)
+	fc := &fakeClient{
+		getBlock: func(ctx context.Context, round uint64) (*block.Block, error) {
+			var blk block.Block
+			blk.Header.Round = latestRound
+			return &blk, nil
+		},
+		getTransactions: func(ctx context.Context, round uint64) ([]*types.UnverifiedTransaction, error) {
+			scanned = append(scanned, round)
+			return included[round], nil
+		},
+		submitTx: func(ctx context.Context, tx *types.UnverifiedTransaction) (cbor.RawMessage, error) {
+			submits++
+			return nil, submitResult
+		},
+	}
+	is := NewIdempotentSubmitter(fc).SetRoundWindow(5)
+	tracked := func() int {
+		is.l.Lock()
+		defer is.l.Unlock()
+		return len(is.submitted)
+	}
+
+	// A timed out submission stays tracked and is resubmitted in case it was not included.
+	txA := &types.UnverifiedTransaction{Body: []byte("a")}
+	submitResult = fmt.Errorf("timeout")
+	_, err := is.SubmitTx(ctx, txA)
+	require.Error(err)
+	require.Equal(1, tracked())
+
+	latestRound = 12
+	submitResult = nil
+	_, err = is.SubmitTx(ctx, txA)
+	require.NoError(err)
+	require.Equal(2, submits, "transaction should be resubmitted when not included")
+	require.EqualValues([]uint64{10, 11, 12}, scanned)
+	require.Equal(0, tracked(), "successfully submitted transactions should no longer be tracked")
+
+	// A retry of an included transaction is not resubmitted.
+	txB := &types.UnverifiedTransaction{Body: []byte("b")}
+	submitResult = fmt.Errorf("timeout")
+	_, err = is.SubmitTx(ctx, txB)
+	require.Error(err)
+	included[13] = []*types.UnverifiedTransaction{txB}
+	latestRound = 14
+	submits = 0
+	_, err = is.SubmitTx(ctx, txB)
+	var incErr *TxAlreadyIncludedError
+	require.True(errors.As(err, &incErr))
+	require.EqualValues(13, incErr.Round)
+	require.Equal(0, submits)
+	require.Equal(0, tracked(), "included transactions should no longer be tracked")
+
+	// Failed but included transactions are no longer tracked.
+	txC := &types.UnverifiedTransaction{Body: []byte("c")}
+	submitResult = &types.FailedCallResult{Module: "test", Code: 1}
+	_, err = is.SubmitTx(ctx, txC)
+	require.Error(err)
+	require.Equal(0, tracked())
+
+	// The inclusion scan is bounded by the round window.
+	txD := &types.UnverifiedTransaction{Body: []byte("d")}
+	submitResult = fmt.Errorf("timeout")
+	_, err = is.SubmitTx(ctx, txD)
+	require.Error(err)
+	latestRound = 18
+	scanned = nil
+	_, err = is.SubmitTx(ctx, txD)
+	require.Error(err)
+	require.EqualValues([]uint64{14, 15, 16, 17, 18}, scanned)
+
+	// Once the round window has passed, the transaction is no longer tracked and no inclusion
+	// scan is performed.
+	latestRound = 25
+	scanned = nil
+	_, err = is.SubmitTx(ctx, txD)
+	require.Error(err)
+	require.Empty(scanned, "rounds outside the window should not be scanned")
+
+	// Transactions are pruned once their round window has passed.
+	latestRound = 100
+	txE := &types.UnverifiedTransaction{Body: []byte("e")}
+	_, err = is.SubmitTx(ctx, txE)
+	require.Error(err)
+	require.Equal(1, tracked(), "expired transactions should be pruned")
+}
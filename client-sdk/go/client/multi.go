@@ -0,0 +1,42 @@
+package client
+
+import (
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+)
+
+// MultiClient is a client for multiple runtimes sharing the same gRPC connection.
+type MultiClient struct {
+	mu sync.Mutex
+
+	conn    *grpc.ClientConn
+	clients map[common.Namespace]RuntimeClient
+}
+
+// ForRuntime returns a runtime client for the given runtime.
+//
+// Runtime clients are created on demand and cached, so repeated calls for the same runtime
+// return the same client (which caches the runtime's chain context).
+func (mc *MultiClient) ForRuntime(runtimeID common.Namespace) RuntimeClient {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if rc, ok := mc.clients[runtimeID]; ok {
+		return rc
+	}
+	rc := New(mc.conn, runtimeID)
+	mc.clients[runtimeID] = rc
+	return rc
+}
+
+// NewMulti creates a new client for multiple runtimes. All calls are made over the given gRPC
+// connection.
+func NewMulti(conn *grpc.ClientConn) *MultiClient {
+	return &MultiClient{
+		conn:    conn,
+		clients: make(map[common.Namespace]RuntimeClient),
+	}
+}
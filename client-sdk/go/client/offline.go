@@ -0,0 +1,67 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// OfflineParams are the transaction parameters that would otherwise be obtained from a node
+// and must be supplied manually when building transactions offline.
+type OfflineParams struct {
+	// Nonce is the signer's account nonce.
+	Nonce uint64
+	// Gas is the maximum amount of gas that can be used by the transaction.
+	Gas uint64
+	// FeeAmount is the fee amount to be paid by the signer.
+	FeeAmount types.BaseUnits
+}
+
+// OfflineBuilder builds and signs transactions without requiring any node connection, e.g.,
+// for hardware wallets and air-gapped signing workflows.
+type OfflineBuilder struct {
+	chainContext signature.Context
+	params       OfflineParams
+}
+
+// NewOfflineBuilder creates a new offline transaction builder for the given runtime.
+//
+// The consensus chain context must be supplied manually and is used to derive the runtime's
+// chain domain separation context.
+func NewOfflineBuilder(runtimeID common.Namespace, consensusChainContext string, params OfflineParams) *OfflineBuilder {
+	return &OfflineBuilder{
+		chainContext: signature.DeriveChainContext(runtimeID, consensusChainContext),
+		params:       params,
+	}
+}
+
+// ChainContext returns the runtime's chain domain separation context used for signing.
+func (ob *OfflineBuilder) ChainContext() signature.Context {
+	return ob.chainContext
+}
+
+// Build builds an unsigned transaction calling the given method with the given body, using the
+// configured fee parameters.
+func (ob *OfflineBuilder) Build(method string, body interface{}) *types.Transaction {
+	tx := types.NewTransaction(nil, method, body)
+	tx.AuthInfo.Fee.Gas = ob.params.Gas
+	if !ob.params.FeeAmount.Amount.IsZero() {
+		tx.AuthInfo.Fee.Amount = ob.params.FeeAmount
+	}
+	return tx
+}
+
+// Sign appends the given signer with the configured nonce to the transaction and signs it,
+// producing a signed transaction ready for submission.
+func (ob *OfflineBuilder) Sign(tx *types.Transaction, signer signature.Signer) (*types.UnverifiedTransaction, error) {
+	tx.AppendAuthSignature(signer.Public(), ob.params.Nonce)
+
+	ts := tx.PrepareForSigning()
+	if err := ts.AppendSign(ob.chainContext, signer); err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return ts.UnverifiedTransaction(), nil
+}
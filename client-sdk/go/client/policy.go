@@ -0,0 +1,189 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+const methodAccountsTransfer = "accounts.Transfer"
+
+// spendingMethods are the known methods that move funds out of the signer's account.
+var spendingMethods = map[string]bool{
+	methodAccountsTransfer: true,
+	"consensus.Deposit":    true,
+	"consensus.Withdraw":   true,
+}
+
+// spendingCall are the common fields of the call bodies of the known spending methods.
+type spendingCall struct {
+	To     *types.Address   `json:"to,omitempty"`
+	Amount *types.BaseUnits `json:"amount,omitempty"`
+}
+
+// SpendPolicy is a spending policy enforced by the PolicySigner.
+type SpendPolicy struct {
+	// AllowedMethods is the list of methods that may be signed. In case it is empty, all methods
+	// are allowed.
+	AllowedMethods []string
+	// MaxAmount is the maximum amount that may be spent by a single transaction, including the
+	// transaction fee and the moved amount. Spending any other denomination is refused. In case
+	// it is nil, any amount is allowed.
+	//
+	// In case either MaxAmount or AllowedRecipients is set, only the known spending methods
+	// (whose effects can be inspected) may be signed.
+	MaxAmount *types.BaseUnits
+	// AllowedRecipients is the list of allowed recipients of the known spending methods. For
+	// deposits and withdrawals the recipient is the account of the first transaction signer. In
+	// case it is empty, any recipient is allowed.
+	AllowedRecipients []types.Address
+	// AllowNonTransactions allows signing messages that are not transactions (e.g., messages
+	// signed under a raw context). Such messages are not inspected.
+	AllowNonTransactions bool
+}
+
+func (sp *SpendPolicy) check(tx *types.Transaction) error {
+	if len(sp.AllowedMethods) > 0 {
+		var allowed bool
+		for _, m := range sp.AllowedMethods {
+			if m == tx.Call.Method {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("method '%s' not allowed", tx.Call.Method)
+		}
+	}
+
+	spend := make(map[types.Denomination]*types.Quantity)
+	addSpend := func(bu *types.BaseUnits) error {
+		if bu.Amount.IsZero() {
+			return nil
+		}
+		total, ok := spend[bu.Denomination]
+		if !ok {
+			total = quantity.NewQuantity()
+			spend[bu.Denomination] = total
+		}
+		return total.Add(&bu.Amount)
+	}
+
+	// The fee is always paid by the signers so it counts towards the spent amount.
+	if err := addSpend(&tx.AuthInfo.Fee.Amount); err != nil {
+		return fmt.Errorf("malformed fee: %w", err)
+	}
+
+	if !spendingMethods[tx.Call.Method] {
+		// The effects of other methods cannot be inspected so they may move arbitrary amounts to
+		// arbitrary recipients.
+		if sp.MaxAmount != nil || len(sp.AllowedRecipients) > 0 {
+			return fmt.Errorf("method '%s' cannot be inspected", tx.Call.Method)
+		}
+		return nil
+	}
+
+	var body spendingCall
+	if err := cbor.Unmarshal(tx.Call.Body, &body); err != nil {
+		return fmt.Errorf("malformed call body: %w", err)
+	}
+	if body.Amount == nil {
+		return fmt.Errorf("missing amount in call body")
+	}
+	if err := addSpend(body.Amount); err != nil {
+		return fmt.Errorf("malformed amount: %w", err)
+	}
+
+	if len(sp.AllowedRecipients) > 0 {
+		to, err := spendingRecipient(tx, &body)
+		if err != nil {
+			return err
+		}
+		var allowed bool
+		for _, addr := range sp.AllowedRecipients {
+			if addr.Equal(to) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("recipient %s not allowed", to)
+		}
+	}
+
+	if sp.MaxAmount != nil {
+		for denom, total := range spend {
+			if denom != sp.MaxAmount.Denomination {
+				return fmt.Errorf("denomination '%s' not allowed", denom)
+			}
+			if total.Cmp(&sp.MaxAmount.Amount) > 0 {
+				return fmt.Errorf("spent amount %s (including fee) exceeds limit %s", total, sp.MaxAmount.Amount.String())
+			}
+		}
+	}
+	return nil
+}
+
+// spendingRecipient returns the account that receives the funds moved by a known spending method.
+func spendingRecipient(tx *types.Transaction, body *spendingCall) (types.Address, error) {
+	if body.To != nil {
+		return *body.To, nil
+	}
+	if tx.Call.Method == methodAccountsTransfer {
+		return types.Address{}, fmt.Errorf("missing recipient in call body")
+	}
+
+	// Deposits and withdrawals move funds to the (consensus or runtime) account of the first
+	// transaction signer.
+	if len(tx.AuthInfo.SignerInfo) == 0 {
+		return types.Address{}, fmt.Errorf("missing transaction signer")
+	}
+	to, err := tx.AuthInfo.SignerInfo[0].AddressSpec.Address()
+	if err != nil {
+		return types.Address{}, fmt.Errorf("malformed signer address: %w", err)
+	}
+	return to, nil
+}
+
+// PolicySigner is a signer that inspects each transaction being signed and refuses to sign it in
+// case it violates the configured spending policy. Any transaction that cannot be fully inspected
+// (e.g., because the call body of a known spending method is malformed) is refused.
+type PolicySigner struct {
+	signature.Signer
+
+	policy SpendPolicy
+}
+
+// ContextSign generates a signature with the private key over the context and message after
+// checking the message against the spending policy.
+func (ps *PolicySigner) ContextSign(context, message []byte) ([]byte, error) {
+	if !bytes.HasPrefix(context, types.SignatureContextBase) {
+		if !ps.policy.AllowNonTransactions {
+			return nil, fmt.Errorf("policy: signing non-transaction messages not allowed")
+		}
+		return ps.Signer.ContextSign(context, message)
+	}
+
+	var tx types.Transaction
+	if err := cbor.Unmarshal(message, &tx); err != nil {
+		return nil, fmt.Errorf("policy: malformed transaction: %w", err)
+	}
+	if err := ps.policy.check(&tx); err != nil {
+		return nil, fmt.Errorf("policy: %w", err)
+	}
+	return ps.Signer.ContextSign(context, message)
+}
+
+// NewPolicySigner wraps the given signer so that only transactions satisfying the given spending
+// policy are signed.
+func NewPolicySigner(inner signature.Signer, policy SpendPolicy) *PolicySigner {
+	return &PolicySigner{
+		Signer: inner,
+		policy: policy,
+	}
+}
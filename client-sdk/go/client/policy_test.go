@@ -0,0 +1,119 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func testChainContext() signature.Context {
+	var runtimeID common.Namespace
+	_ = runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+	return signature.DeriveChainContext(runtimeID, "643fb06848be7e970af3b5b2d772eb8cfb30499c8162bc18ac03df2f5e22520e")
+}
+
+func testNativeUnits(amount uint64) types.BaseUnits {
+	return types.NewBaseUnits(*quantity.NewFromUint64(amount), types.NativeDenomination)
+}
+
+func TestPolicySigner(t *testing.T) {
+	require := require.New(t)
+
+	inner := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: policy"))
+	allowedSigner := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: allowed"))
+	allowed := types.NewAddress(allowedSigner.Public())
+	other := types.NewAddress(ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: other")).Public())
+
+	maxAmount := testNativeUnits(100)
+	signer := NewPolicySigner(inner, SpendPolicy{
+		AllowedMethods:    []string{"accounts.Transfer", "consensus.Withdraw", "test.Noop"},
+		MaxAmount:         &maxAmount,
+		AllowedRecipients: []types.Address{allowed},
+	})
+	txCtx := testChainContext().New(types.SignatureContextBase)
+
+	transfer := func(to *types.Address, amount *types.BaseUnits, fee uint64) *types.Transaction {
+		return types.NewTransaction(&types.Fee{Amount: testNativeUnits(fee), Gas: 1000}, "accounts.Transfer", &spendingCall{
+			To:     to,
+			Amount: amount,
+		})
+	}
+
+	// Deposits and withdrawals move funds to the account of the first signer.
+	signedBy := func(pk signature.PublicKey, method string, amount *types.BaseUnits) *types.Transaction {
+		tx := types.NewTransaction(nil, method, &spendingCall{Amount: amount})
+		tx.AppendAuthSignature(pk, 0)
+		return tx
+	}
+
+	amount60 := testNativeUnits(60)
+	amount100 := testNativeUnits(100)
+	amount101 := testNativeUnits(101)
+	foreign := types.NewBaseUnits(*quantity.NewFromUint64(1), types.Denomination("FOO"))
+
+	for _, tc := range []struct {
+		tx    *types.Transaction
+		valid bool
+		msg   string
+	}{
+		{transfer(&allowed, &amount60, 40), true, "transfer within limit including fee should be allowed"},
+		{transfer(&allowed, &amount100, 0), true, "transfer at limit should be allowed"},
+		{transfer(&allowed, &amount101, 0), false, "transfer over limit should be refused"},
+		{transfer(&allowed, &amount60, 41), false, "transfer over limit due to fee should be refused"},
+		{transfer(&other, &amount60, 0), false, "transfer to disallowed recipient should be refused"},
+		{transfer(nil, &amount60, 0), false, "transfer without recipient should be refused"},
+		{transfer(&allowed, nil, 0), false, "transfer without amount should be refused"},
+		{transfer(&allowed, &foreign, 0), false, "transfer of disallowed denomination should be refused"},
+		{types.NewTransaction(&types.Fee{Amount: testNativeUnits(10), Gas: 1000}, "test.Noop", nil), false, "uninspectable call should be refused"},
+		{types.NewTransaction(nil, "accounts.Transfer", "malformed"), false, "malformed call body should be refused"},
+		{signedBy(allowedSigner.Public(), "consensus.Deposit", &amount60), false, "disallowed method should be refused"},
+		{signedBy(allowedSigner.Public(), "consensus.Withdraw", &amount60), true, "withdrawal to allowed recipient within limit should be allowed"},
+		{signedBy(allowedSigner.Public(), "consensus.Withdraw", &amount101), false, "withdrawal over limit should be refused"},
+		{signedBy(inner.Public(), "consensus.Withdraw", &amount60), false, "withdrawal to disallowed recipient should be refused"},
+		{types.NewTransaction(nil, "consensus.Withdraw", &spendingCall{Amount: &amount60}), false, "withdrawal without signer should be refused"},
+	} {
+		sig, err := signer.ContextSign(txCtx, cbor.Marshal(tc.tx))
+		if tc.valid {
+			require.NoError(err, tc.msg)
+			require.NotEmpty(sig, tc.msg)
+		} else {
+			require.Error(err, tc.msg)
+		}
+	}
+
+	_, err := signer.ContextSign(txCtx, []byte("not a transaction"))
+	require.Error(err, "malformed transactions should be refused")
+
+	_, err = signer.ContextSign([]byte("oasis-runtime-sdk-test/raw: v0"), []byte("message"))
+	require.Error(err, "non-transaction messages should be refused by default")
+
+	// Uninspectable methods are refused whenever an amount or recipient limit is set, even in case
+	// all methods are allowed.
+	noop := types.NewTransaction(nil, "test.Noop", nil)
+	maxOnly := NewPolicySigner(inner, SpendPolicy{MaxAmount: &maxAmount})
+	_, err = maxOnly.ContextSign(txCtx, cbor.Marshal(noop))
+	require.Error(err, "uninspectable call should be refused when an amount limit is set")
+	recipientsOnly := NewPolicySigner(inner, SpendPolicy{AllowedRecipients: []types.Address{allowed}})
+	_, err = recipientsOnly.ContextSign(txCtx, cbor.Marshal(noop))
+	require.Error(err, "uninspectable call should be refused when a recipient limit is set")
+	_, err = recipientsOnly.ContextSign(txCtx, cbor.Marshal(signedBy(inner.Public(), "consensus.Deposit", &amount60)))
+	require.Error(err, "deposit to disallowed recipient should be refused")
+	_, err = recipientsOnly.ContextSign(txCtx, cbor.Marshal(signedBy(allowedSigner.Public(), "consensus.Deposit", &amount60)))
+	require.NoError(err, "deposit to allowed recipient should be allowed")
+	methodsOnly := NewPolicySigner(inner, SpendPolicy{AllowedMethods: []string{"test.Noop"}})
+	_, err = methodsOnly.ContextSign(txCtx, cbor.Marshal(noop))
+	require.NoError(err, "uninspectable call should be allowed without amount or recipient limits")
+
+	permissive := NewPolicySigner(inner, SpendPolicy{AllowNonTransactions: true})
+	_, err = permissive.ContextSign([]byte("oasis-runtime-sdk-test/raw: v0"), []byte("message"))
+	require.NoError(err, "non-transaction messages should be allowed when configured")
+}
@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+const (
+	// DefaultRetryInitialInterval is the default initial interval between retries.
+	DefaultRetryInitialInterval = 100 * time.Millisecond
+	// DefaultRetryMaxInterval is the default maximum interval between retries.
+	DefaultRetryMaxInterval = 5 * time.Second
+	// DefaultRetryMaxAttempts is the default maximum number of attempts.
+	DefaultRetryMaxAttempts = 5
+)
+
+// RetryOptions are options for retrying read queries.
+type RetryOptions struct {
+	// InitialInterval is the interval before the first retry. It is doubled after each retry
+	// (but never exceeds MaxInterval). In case it is zero, DefaultRetryInitialInterval is used.
+	InitialInterval time.Duration
+	// MaxInterval is the maximum interval between retries. In case it is zero,
+	// DefaultRetryMaxInterval is used.
+	MaxInterval time.Duration
+	// MaxAttempts is the maximum number of attempts (including the first one). In case it is
+	// zero, DefaultRetryMaxAttempts is used.
+	MaxAttempts int
+}
+
+// isRetryable checks whether the given error is a transient gRPC error worth retrying. The gRPC
+// status is also found in case the error has been wrapped.
+func isRetryable(err error) bool {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return false
+	}
+	switch grpcErr.GRPCStatus().Code() {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+type retryingClient struct {
+	RuntimeClient
+
+	opts RetryOptions
+}
+
+func (rc *retryingClient) retry(ctx context.Context, fn func() error) error {
+	interval := rc.opts.InitialInterval
+	if interval > rc.opts.MaxInterval {
+		interval = rc.opts.MaxInterval
+	}
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !isRetryable(err) || attempt >= rc.opts.MaxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > rc.opts.MaxInterval {
+			interval = rc.opts.MaxInterval
+		}
+	}
+}
+
+// Implements RuntimeClient.
+func (rc *retryingClient) GetInfo(ctx context.Context) (info *types.RuntimeInfo, err error) {
+	err = rc.retry(ctx, func() error {
+		info, err = rc.RuntimeClient.GetInfo(ctx)
+		return err
+	})
+	return
+}
+
+// Implements InfoRefresher.
+func (rc *retryingClient) RefreshInfo(ctx context.Context) (info *types.RuntimeInfo, err error) {
+	ir, ok := rc.RuntimeClient.(InfoRefresher)
+	if !ok {
+		return nil, fmt.Errorf("wrapped runtime client does not support refreshing runtime info")
+	}
+	err = rc.retry(ctx, func() error {
+		info, err = ir.RefreshInfo(ctx)
+		return err
+	})
+	return
+}
+
+// Implements RuntimeClient.
+func (rc *retryingClient) GetGenesisBlock(ctx context.Context) (blk *block.Block, err error) {
+	err = rc.retry(ctx, func() error {
+		blk, err = rc.RuntimeClient.GetGenesisBlock(ctx)
+		return err
+	})
+	return
+}
+
+// Implements RuntimeClient.
+func (rc *retryingClient) GetBlock(ctx context.Context, round uint64) (blk *block.Block, err error) {
+	err = rc.retry(ctx, func() error {
+		blk, err = rc.RuntimeClient.GetBlock(ctx, round)
+		return err
+	})
+	return
+}
+
+// Implements RuntimeClient.
+func (rc *retryingClient) GetRoundMetadata(ctx context.Context, round uint64) (md *RoundMetadata, err error) {
+	err = rc.retry(ctx, func() error {
+		md, err = rc.RuntimeClient.GetRoundMetadata(ctx, round)
+		return err
+	})
+	return
+}
+
+// Implements RuntimeClient.
+func (rc *retryingClient) GetTransactions(ctx context.Context, round uint64) (txs []*types.UnverifiedTransaction, err error) {
+	err = rc.retry(ctx, func() error {
+		txs, err = rc.RuntimeClient.GetTransactions(ctx, round)
+		return err
+	})
+	return
+}
+
+// Implements RuntimeClient.
+func (rc *retryingClient) GetTransactionsBySigner(ctx context.Context, round uint64, address types.Address) (txs []*types.UnverifiedTransaction, err error) {
+	err = rc.retry(ctx, func() error {
+		txs, err = rc.RuntimeClient.GetTransactionsBySigner(ctx, round, address)
+		return err
+	})
+	return
+}
+
+// Implements RuntimeClient.
+func (rc *retryingClient) GetEvents(ctx context.Context, round uint64) (evs []*coreClient.Event, err error) {
+	err = rc.retry(ctx, func() error {
+		evs, err = rc.RuntimeClient.GetEvents(ctx, round)
+		return err
+	})
+	return
+}
+
+// Implements RuntimeClient.
+func (rc *retryingClient) Query(ctx context.Context, round uint64, method string, args, rsp interface{}) error {
+	return rc.retry(ctx, func() error {
+		return rc.RuntimeClient.Query(ctx, round, method, args, rsp)
+	})
+}
+
+// Implements RuntimeClient.
+func (rc *retryingClient) QueryAt(ctx context.Context, when RoundSelector, method string, args, rsp interface{}) error {
+	return rc.retry(ctx, func() error {
+		return rc.RuntimeClient.QueryAt(ctx, when, method, args, rsp)
+	})
+}
+
+// Implements RuntimeClient.
+func (rc *retryingClient) Snapshot(ctx context.Context, when RoundSelector, reads ...ReadSpec) (snap *Snapshot, err error) {
+	err = rc.retry(ctx, func() error {
+		snap, err = rc.RuntimeClient.Snapshot(ctx, when, reads...)
+		return err
+	})
+	return
+}
+
+// WithQueryRetry configures the runtime client so that read methods (queries and block,
+// transaction and event lookups, including composite ones like QueryAt and Snapshot) are retried
+// with exponential backoff on transient gRPC errors. Transaction submission is never retried.
+//
+// See NewRetryingClient for details.
+func WithQueryRetry(opts RetryOptions) Option {
+	return func(rc *runtimeClient) {
+		rc.queryRetry = &opts
+	}
+}
+
+// NewRetryingClient wraps the given runtime client so that read methods (queries and block,
+// transaction and event lookups, including composite ones like QueryAt and Snapshot) are retried
+// with exponential backoff on transient gRPC errors. Other methods are passed through unchanged.
+//
+// Transaction submission is never retried as that could result in the same transaction being
+// submitted multiple times (see IdempotentSubmitter for safe resubmission).
+func NewRetryingClient(rc RuntimeClient, opts RetryOptions) RuntimeClient {
+	if opts.InitialInterval == 0 {
+		opts.InitialInterval = DefaultRetryInitialInterval
+	}
+	if opts.MaxInterval == 0 {
+		opts.MaxInterval = DefaultRetryMaxInterval
+	}
+	if opts.MaxAttempts == 0 {
+		opts.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	return &retryingClient{
+		RuntimeClient: rc,
+		opts:          opts,
+	}
+}
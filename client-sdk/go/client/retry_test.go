@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestRetryingClient(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	transientErr := status.Error(codes.Unavailable, "unavailable")
+
+	var (
+		calls    int
+		failures int
+		failErr  error
+	)
+	fc := &fakeClient{
+		query: func(ctx context.Context, round uint64, method string, args interface{}) (interface{}, error) {
+			calls++
+			if calls <= failures {
+				return nil, failErr
+			}
+			return uint64(42), nil
+		},
+		getTransactions: func(ctx context.Context, round uint64) ([]*types.UnverifiedTransaction, error) {
+			calls++
+			if calls <= failures {
+				return nil, failErr
+			}
+			return []*types.UnverifiedTransaction{{}}, nil
+		},
+	}
+	reset := func(f int, err error) {
+		calls, failures, failErr = 0, f, err
+	}
+
+	// The initial interval must be capped by the maximum interval, otherwise this would hang.
+	rc := NewRetryingClient(fc, RetryOptions{
+		InitialInterval: time.Hour,
+		MaxInterval:     time.Millisecond,
+		MaxAttempts:     3,
+	})
+
+	var rsp uint64
+	reset(2, transientErr)
+	err := rc.Query(ctx, RoundLatest, "test.Query", nil, &rsp)
+	require.NoError(err, "transient errors should be retried")
+	require.EqualValues(42, rsp)
+	require.Equal(3, calls)
+
+	reset(3, transientErr)
+	err = rc.Query(ctx, RoundLatest, "test.Query", nil, &rsp)
+	require.Error(err, "retries should stop after the maximum number of attempts")
+	require.Equal(3, calls)
+
+	reset(2, fmt.Errorf("failed to query: %w", fmt.Errorf("wrapped: %w", transientErr)))
+	err = rc.Query(ctx, RoundLatest, "test.Query", nil, &rsp)
+	require.NoError(err, "wrapped transient errors should be retried")
+	require.Equal(3, calls)
+
+	reset(1, fmt.Errorf("permanent failure"))
+	err = rc.Query(ctx, RoundLatest, "test.Query", nil, &rsp)
+	require.Error(err, "non-transient errors should not be retried")
+	require.Equal(1, calls)
+
+	reset(2, transientErr)
+	txs, err := rc.GetTransactions(ctx, 1)
+	require.NoError(err, "composite read methods should be retried")
+	require.Len(txs, 1)
+	require.Equal(3, calls)
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	reset(1, transientErr)
+	rc = NewRetryingClient(fc, RetryOptions{InitialInterval: time.Hour, MaxInterval: time.Hour})
+	err = rc.Query(cancelCtx, RoundLatest, "test.Query", nil, &rsp)
+	require.ErrorIs(err, context.Canceled, "retries should stop once the context is canceled")
+}
+
+func TestIsRetryable(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"Unavailable", status.Error(codes.Unavailable, "unavailable"), true},
+		{"ResourceExhausted", status.Error(codes.ResourceExhausted, "exhausted"), true},
+		{"Aborted", status.Error(codes.Aborted, "aborted"), true},
+		{"NotFound", status.Error(codes.NotFound, "not found"), false},
+		{"Wrapped", fmt.Errorf("failed to fetch block: %w", status.Error(codes.Unavailable, "unavailable")), true},
+		{"WrappedTwice", fmt.Errorf("a: %w", fmt.Errorf("b: %w", status.Error(codes.Aborted, "aborted"))), true},
+		{"WrappedPermanent", fmt.Errorf("a: %w", status.Error(codes.InvalidArgument, "invalid")), false},
+		{"NonGRPC", fmt.Errorf("permanent failure"), false},
+	} {
+		require.Equal(tc.retryable, isRetryable(tc.err), tc.name)
+	}
+}
+
+func TestWithQueryRetry(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	rc := New(nil, runtimeID, WithQueryRetry(RetryOptions{MaxAttempts: 2}))
+	retrying, ok := rc.(*retryingClient)
+	require.True(ok, "client should retry queries")
+	require.EqualValues(2, retrying.opts.MaxAttempts)
+	require.Equal(DefaultRetryInitialInterval, retrying.opts.InitialInterval, "defaults should be applied")
+
+	_, ok = New(nil, runtimeID).(*runtimeClient)
+	require.True(ok, "client should not retry queries by default")
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+type roundSelectorKind uint8
+
+const (
+	roundSelectorLatest roundSelectorKind = iota
+	roundSelectorGenesis
+	roundSelectorSpecific
+)
+
+// RoundSelector selects the runtime round to use.
+type RoundSelector struct {
+	kind  roundSelectorKind
+	round uint64
+}
+
+var (
+	// SelectLatest selects the latest round.
+	SelectLatest = RoundSelector{kind: roundSelectorLatest}
+	// SelectGenesis selects the genesis round.
+	SelectGenesis = RoundSelector{kind: roundSelectorGenesis}
+)
+
+// SelectRound selects the given round.
+func SelectRound(round uint64) RoundSelector {
+	if round == RoundLatest {
+		return SelectLatest
+	}
+	return RoundSelector{kind: roundSelectorSpecific, round: round}
+}
+
+// String returns a string representation of the round selector.
+func (rs RoundSelector) String() string {
+	switch rs.kind {
+	case roundSelectorLatest:
+		return "latest"
+	case roundSelectorGenesis:
+		return "genesis"
+	default:
+		return fmt.Sprintf("round %d", rs.round)
+	}
+}
+
+// resolveRound resolves the round selector into a concrete round number (or RoundLatest).
+func (rc *runtimeClient) resolveRound(ctx context.Context, when RoundSelector) (uint64, error) {
+	switch when.kind {
+	case roundSelectorLatest:
+		return RoundLatest, nil
+	case roundSelectorGenesis:
+		blk, err := rc.GetGenesisBlock(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch genesis block: %w", err)
+		}
+		return blk.Header.Round, nil
+	default:
+		if _, err := rc.GetBlock(ctx, when.round); err != nil {
+			if isNotFound(err) {
+				return 0, rc.missingRoundError(ctx, when.round, err)
+			}
+			return 0, fmt.Errorf("failed to fetch block for round %d: %w", when.round, err)
+		}
+		return when.round, nil
+	}
+}
+
+// missingRoundError classifies the not found error returned when fetching data for the given
+// round. Rounds beyond the latest round have not been reached yet (a *FutureRoundError), while
+// any other missing round has been pruned (a *RoundPrunedError) as the node retains a contiguous
+// range of rounds ending at the latest round.
+func (rc *runtimeClient) missingRoundError(ctx context.Context, round uint64, err error) error {
+	latest, lerr := rc.GetBlock(ctx, RoundLatest)
+	if lerr != nil {
+		return fmt.Errorf("round %d not found (%s) and failed to fetch latest block: %w", round, err, lerr)
+	}
+	if round > latest.Header.Round {
+		return &FutureRoundError{Round: round, LatestRound: latest.Header.Round, Err: err}
+	}
+	return &RoundPrunedError{Round: round, Err: err}
+}
+
+// Implements RuntimeClient.
+func (rc *runtimeClient) QueryAt(ctx context.Context, when RoundSelector, method string, args, rsp interface{}) error {
+	round, err := rc.resolveRound(ctx, when)
+	if err != nil {
+		return err
+	}
+	return rc.Query(ctx, round, method, args, rsp)
+}
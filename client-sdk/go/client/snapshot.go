@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+// ReadSpec is a specification of a single query that is part of a snapshot.
+type ReadSpec struct {
+	// Key is the key under which the result is stored in the snapshot.
+	Key string
+	// Method is the query method name (e.g., "accounts.Balances").
+	Method string
+	// Args are the query arguments.
+	Args interface{}
+}
+
+// Snapshot is a set of query results that were all obtained at the same round.
+type Snapshot struct {
+	// Round is the round at which all of the queries were performed.
+	Round uint64
+	// Results are the raw query results keyed by their ReadSpec key.
+	Results map[string]cbor.RawMessage
+}
+
+// Decode decodes the result stored under the given key into rsp.
+func (s *Snapshot) Decode(key string, rsp interface{}) error {
+	raw, ok := s.Results[key]
+	if !ok {
+		return fmt.Errorf("no result for key '%s'", key)
+	}
+	if err := cbor.Unmarshal(raw, rsp); err != nil {
+		return fmt.Errorf("failed to unmarshal result for key '%s': %w", key, err)
+	}
+	return nil
+}
+
+// Implements RuntimeClient.
+func (rc *runtimeClient) Snapshot(ctx context.Context, when RoundSelector, reads ...ReadSpec) (*Snapshot, error) {
+	keys := make(map[string]bool, len(reads))
+	for _, rs := range reads {
+		if keys[rs.Key] {
+			return nil, fmt.Errorf("duplicate snapshot key '%s'", rs.Key)
+		}
+		keys[rs.Key] = true
+	}
+
+	round, err := rc.resolveRound(ctx, when)
+	if err != nil {
+		return nil, err
+	}
+	if round == RoundLatest {
+		// Pin the latest round so that all queries observe the same state.
+		blk, err := rc.GetBlock(ctx, RoundLatest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch latest block: %w", err)
+		}
+		round = blk.Header.Round
+	}
+
+	results := make([]cbor.RawMessage, len(reads))
+	errs := make([]error, len(reads))
+	var wg sync.WaitGroup
+	for i := range reads {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = rc.Query(ctx, round, reads[i].Method, reads[i].Args, &results[i])
+		}(i)
+	}
+	wg.Wait()
+
+	snapshot := &Snapshot{
+		Round:   round,
+		Results: make(map[string]cbor.RawMessage, len(reads)),
+	}
+	for i, rs := range reads {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("query '%s' (%s) failed: %w", rs.Key, rs.Method, errs[i])
+		}
+		snapshot.Results[rs.Key] = results[i]
+	}
+	return snapshot, nil
+}
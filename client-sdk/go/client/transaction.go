@@ -51,6 +51,13 @@ func (tb *TransactionBuilder) AppendAuthMultisig(config *types.MultisigConfig, n
 	return tb
 }
 
+// AppendAuthAccount appends a new transaction signer information with the given account's
+// signature address specification to the transaction.
+func (tb *TransactionBuilder) AppendAuthAccount(account *types.Account, nonce uint64) *TransactionBuilder {
+	tb.tx.AppendAuthAccount(account, nonce)
+	return tb
+}
+
 // GetTransaction returns the underlying unsigned transaction.
 func (tb *TransactionBuilder) GetTransaction() *types.Transaction {
 	return tb.tx
@@ -0,0 +1,108 @@
+package signature
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// AuditHook is a function invoked before every signing operation performed by an
+// AuditingSigner. For raw signatures, the context is nil. In case it returns an error, the
+// signing operation is refused.
+type AuditHook func(context, message []byte) error
+
+// AuditingSigner is a Signer that wraps another signer and invokes a hook on every signing
+// operation, enabling applications to log or enforce policy on every produced signature.
+type AuditingSigner struct {
+	inner Signer
+	hook  AuditHook
+	count uint64
+}
+
+// AuditingRawSigner is an AuditingSigner wrapping a RawSigner, which also invokes the hook on
+// every raw signing operation.
+type AuditingRawSigner struct {
+	AuditingSigner
+
+	raw RawSigner
+}
+
+// NewAuditingSigner wraps the given signer so that the given hook is invoked on every signing
+// operation.
+//
+// In case the given signer is a RawSigner, the returned signer is an *AuditingRawSigner and
+// otherwise it is an *AuditingSigner, so that the returned signer supports the same signing
+// operations as the wrapped one.
+func NewAuditingSigner(inner Signer, hook AuditHook) Signer {
+	if raw, ok := inner.(RawSigner); ok {
+		return &AuditingRawSigner{
+			AuditingSigner: AuditingSigner{
+				inner: inner,
+				hook:  hook,
+			},
+			raw: raw,
+		}
+	}
+	return &AuditingSigner{
+		inner: inner,
+		hook:  hook,
+	}
+}
+
+// Count returns the number of signatures produced by the signer.
+func (as *AuditingSigner) Count() uint64 {
+	return atomic.LoadUint64(&as.count)
+}
+
+// Public returns the PublicKey of the wrapped signer.
+func (as *AuditingSigner) Public() PublicKey {
+	return as.inner.Public()
+}
+
+// audit invokes the audit hook (if any) and returns an error in case it refuses signing.
+func (as *AuditingSigner) audit(context, message []byte) error {
+	if as.hook == nil {
+		return nil
+	}
+	if err := as.hook(context, message); err != nil {
+		return fmt.Errorf("signature: signing refused by audit hook: %w", err)
+	}
+	return nil
+}
+
+// ContextSign invokes the audit hook and generates a signature over the context and message
+// using the wrapped signer.
+func (as *AuditingSigner) ContextSign(context, message []byte) ([]byte, error) {
+	if err := as.audit(context, message); err != nil {
+		return nil, err
+	}
+	sig, err := as.inner.ContextSign(context, message)
+	if err != nil {
+		return nil, err
+	}
+	atomic.AddUint64(&as.count, 1)
+	return sig, nil
+}
+
+// String returns the string representation of the wrapped signer.
+func (as *AuditingSigner) String() string {
+	return as.inner.String()
+}
+
+// Reset tears down the wrapped signer.
+func (as *AuditingSigner) Reset() {
+	as.inner.Reset()
+}
+
+// Sign invokes the audit hook and generates a signature over the raw message using the wrapped
+// signer.
+func (ars *AuditingRawSigner) Sign(message []byte) ([]byte, error) {
+	if err := ars.audit(nil, message); err != nil {
+		return nil, err
+	}
+	sig, err := ars.raw.Sign(message)
+	if err != nil {
+		return nil, err
+	}
+	atomic.AddUint64(&ars.count, 1)
+	return sig, nil
+}
@@ -0,0 +1,90 @@
+package signature
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testSigner struct{}
+
+func (testSigner) Public() PublicKey {
+	return nil
+}
+
+func (testSigner) ContextSign(context, message []byte) ([]byte, error) {
+	return append(append([]byte{}, context...), message...), nil
+}
+
+func (testSigner) String() string {
+	return "test signer"
+}
+
+func (testSigner) Reset() {
+}
+
+type testRawSigner struct {
+	testSigner
+}
+
+func (testRawSigner) Sign(message []byte) ([]byte, error) {
+	return append([]byte("raw:"), message...), nil
+}
+
+func TestAuditingSigner(t *testing.T) {
+	require := require.New(t)
+
+	type audit struct {
+		context []byte
+		message []byte
+	}
+	var (
+		audited []audit
+		refuse  error
+	)
+	hook := func(context, message []byte) error {
+		if refuse != nil {
+			return refuse
+		}
+		audited = append(audited, audit{context, message})
+		return nil
+	}
+
+	signer := NewAuditingSigner(testSigner{}, hook)
+	_, ok := signer.(RawSigner)
+	require.False(ok, "wrapping a non-raw signer should not produce a RawSigner")
+	as := signer.(*AuditingSigner)
+
+	sig, err := signer.ContextSign([]byte("ctx:"), []byte("hello"))
+	require.NoError(err, "ContextSign")
+	require.EqualValues("ctx:hello", sig, "signature should be produced by the inner signer")
+	require.EqualValues(1, as.Count())
+	require.Equal([]audit{{[]byte("ctx:"), []byte("hello")}}, audited)
+
+	refuse = fmt.Errorf("rate limited")
+	_, err = signer.ContextSign([]byte("ctx:"), []byte("again"))
+	require.Error(err, "ContextSign should fail when the hook refuses signing")
+	require.True(errors.Is(err, refuse), "error should retain the hook error")
+	require.EqualValues(1, as.Count(), "refused signatures should not be counted")
+	refuse = nil
+
+	require.Equal("test signer", signer.String())
+
+	audited = nil
+	rawSigner, ok := NewAuditingSigner(testRawSigner{}, hook).(RawSigner)
+	require.True(ok, "wrapping a raw signer should produce a RawSigner")
+	ars := rawSigner.(*AuditingRawSigner)
+
+	sig, err = rawSigner.Sign([]byte("hello"))
+	require.NoError(err, "Sign")
+	require.EqualValues("raw:hello", sig, "raw signature should be produced by the inner signer")
+	require.EqualValues(1, ars.Count())
+	require.Equal([]audit{{nil, []byte("hello")}}, audited)
+
+	refuse = fmt.Errorf("rate limited")
+	_, err = rawSigner.Sign([]byte("again"))
+	require.Error(err, "Sign should fail when the hook refuses signing")
+	require.EqualValues(1, ars.Count(), "refused signatures should not be counted")
+}
@@ -1,11 +1,29 @@
 package signature
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 )
 
-const chainContextSeparator = " for chain "
+const (
+	chainContextSeparator = " for chain "
+
+	// maxRawContextSize is the maximum size of a raw domain separation context.
+	maxRawContextSize = 255
+
+	// reservedTxContextPrefix is the prefix of the transaction signing context (see
+	// types.SignatureContextBase) which must not be used as a raw context. It is duplicated here
+	// as the types package depends on this package.
+	reservedTxContextPrefix = "oasis-runtime-sdk/tx:"
+)
+
+// rawContextFormat is the expected format of raw domain separation contexts, for example
+// "oasis-runtime-sdk-test/greeting: v0".
+var rawContextFormat = regexp.MustCompile(`^[a-z0-9_-]+(/[a-z0-9_-]+)+: [[:graph:]]+$`)
 
 // Context is the chain domain separation context.
 type Context string
@@ -25,3 +43,48 @@ func DeriveChainContext(runtimeID common.Namespace, consensusChainContext string
 		[]byte(consensusChainContext),
 	).String())
 }
+
+// RawContextSigner produces and verifies signatures under a fixed raw domain separation context
+// which is not bound to any chain.
+//
+// This is useful for runtimes with custom module-authenticated schemes where a module verifies
+// a signature over module-specific data. Such contexts must never be the same as the context
+// used for transaction signing.
+type RawContextSigner struct {
+	context []byte
+}
+
+// Context returns the raw domain separation context.
+func (rs *RawContextSigner) Context() []byte {
+	return append([]byte{}, rs.context...)
+}
+
+// Sign generates a signature over the message under the raw context using the given signer.
+func (rs *RawContextSigner) Sign(signer Signer, message []byte) ([]byte, error) {
+	return signer.ContextSign(rs.context, message)
+}
+
+// Verify returns true iff the signature is valid for the public key over the raw context and
+// message.
+func (rs *RawContextSigner) Verify(pk PublicKey, message, signature []byte) bool {
+	return pk.Verify(rs.context, message, signature)
+}
+
+// NewRawContextSigner creates a new raw context signer for the given context.
+//
+// The context must be of the form "<prefix>/<purpose>: <version>" (e.g.,
+// "oasis-runtime-sdk-test/greeting: v0"), must not exceed 255 bytes and must not be a chain
+// domain separation context or the reserved transaction signing context.
+func NewRawContextSigner(context string) (*RawContextSigner, error) {
+	switch {
+	case len(context) > maxRawContextSize:
+		return nil, fmt.Errorf("signature: raw context too long")
+	case strings.Contains(context, chainContextSeparator):
+		return nil, fmt.Errorf("signature: raw context must not include a chain context")
+	case strings.HasPrefix(context, reservedTxContextPrefix):
+		return nil, fmt.Errorf("signature: raw context must not be the transaction signing context")
+	case !rawContextFormat.MatchString(context):
+		return nil, fmt.Errorf("signature: malformed raw context")
+	}
+	return &RawContextSigner{context: []byte(context)}, nil
+}
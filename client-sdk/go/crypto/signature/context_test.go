@@ -1,6 +1,7 @@
 package signature
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -20,3 +21,32 @@ func TestDeriveChainContext(t *testing.T) {
 	ctx1 := chainCtx.New([]byte("oasis-runtime-sdk/tx: v0"))
 	require.Equal("oasis-runtime-sdk/tx: v0 for chain ca4842870b97a6d5c0d025adce0b6a0dec94d2ba192ede70f96349cfbe3628b9", string(ctx1))
 }
+
+func TestNewRawContextSigner(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		context string
+		valid   bool
+	}{
+		{"oasis-runtime-sdk-test/greeting: v0", true},
+		{"oasis-runtime-sdk/tx: v0", false},
+		{"oasis-runtime-sdk/tx: v1", false},
+		{"my-runtime/module/scheme: v1", true},
+		{"", false},
+		{"greeting: v0", false},
+		{"oasis-runtime-sdk-test/greeting", false},
+		{"oasis-runtime-sdk-test/greeting: ", false},
+		{"Oasis/Greeting: v0", false},
+		{"oasis-runtime-sdk/tx: v0 for chain ca4842870b97a6d5c0d025adce0b6a0dec94d2ba192ede70f96349cfbe3628b9", false},
+		{"oasis/" + strings.Repeat("a", 255) + ": v0", false},
+	} {
+		rs, err := NewRawContextSigner(tc.context)
+		if tc.valid {
+			require.NoError(err, "NewRawContextSigner(%s)", tc.context)
+			require.EqualValues(tc.context, rs.Context())
+		} else {
+			require.Error(err, "NewRawContextSigner(%s)", tc.context)
+		}
+	}
+}
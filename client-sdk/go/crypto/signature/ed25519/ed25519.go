@@ -1,6 +1,8 @@
 package ed25519
 
 import (
+	"crypto/ed25519"
+	"crypto/subtle"
 	"encoding"
 	"encoding/json"
 
@@ -57,13 +59,13 @@ func (pk PublicKey) String() string {
 	return (signature.PublicKey)(pk).String()
 }
 
-// Equal compares vs another public key for equality.
+// Equal compares vs another public key for equality in constant time.
 func (pk PublicKey) Equal(other sdkSignature.PublicKey) bool {
 	opk, ok := other.(PublicKey)
 	if !ok {
 		return false
 	}
-	return (signature.PublicKey)(pk).Equal((signature.PublicKey)(opk))
+	return subtle.ConstantTimeCompare(pk[:], opk[:]) == 1
 }
 
 // Verify returns true iff the signature is valid for the public key over the context and message.
@@ -71,6 +73,14 @@ func (pk PublicKey) Verify(context, message, sig []byte) bool {
 	return signature.PublicKey(pk).Verify(signature.Context(context), message, sig)
 }
 
+// VerifyRaw returns true iff the signature is a valid raw Ed25519 signature for the public key
+// over the message, without any domain separation.
+//
+// This should only be used to verify signatures produced by RawSigner.Sign.
+func (pk PublicKey) VerifyRaw(message, sig []byte) bool {
+	return ed25519.Verify(pk[:], message, sig)
+}
+
 // NewPublicKey creates a new public key from the given Base64 representation or
 // panics.
 func NewPublicKey(text string) (pk PublicKey) {
@@ -0,0 +1,139 @@
+package ed25519
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+
+	sdkSignature "github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+)
+
+func TestEd25519ContextSign(t *testing.T) {
+	require := require.New(t)
+
+	signer := WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: ed25519 context"))
+	pk := signer.Public().(PublicKey)
+
+	var runtimeID common.Namespace
+	_ = runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+	chainCtx := sdkSignature.DeriveChainContext(runtimeID, "0000000000000000000000000000000000000000000000000000000000000001")
+	ctx := chainCtx.New([]byte("oasis-runtime-sdk/tx: v0"))
+	msg := []byte("message")
+
+	sig, err := signer.ContextSign(ctx, msg)
+	require.NoError(err, "ContextSign")
+	require.True(pk.Verify(ctx, msg, sig), "context signature should verify")
+	require.False(pk.Verify([]byte("other context"), msg, sig), "context signature should not verify under another context")
+	require.False(pk.VerifyRaw(msg, sig), "context signature should not verify as a raw signature")
+
+	// The runtime verifies context signatures as Ed25519 signatures over SHA512/256(ctx || msg).
+	h := sha512.New512_256()
+	_, _ = h.Write(ctx)
+	_, _ = h.Write(msg)
+	require.True(ed25519.Verify(pk[:], h.Sum(nil), sig), "context signature should match runtime verification")
+}
+
+// rawTestSigner is an Oasis Core signer that natively supports raw signing.
+type rawTestSigner struct {
+	privateKey ed25519.PrivateKey
+}
+
+func (s *rawTestSigner) Public() coreSignature.PublicKey {
+	var pk coreSignature.PublicKey
+	copy(pk[:], s.privateKey.Public().(ed25519.PublicKey))
+	return pk
+}
+
+func (s *rawTestSigner) ContextSign(context coreSignature.Context, message []byte) ([]byte, error) {
+	return nil, fmt.Errorf("context signing not supported")
+}
+
+func (s *rawTestSigner) Sign(message []byte) ([]byte, error) {
+	return ed25519.Sign(s.privateKey, message), nil
+}
+
+func (s *rawTestSigner) String() string {
+	return "raw test signer"
+}
+
+func (s *rawTestSigner) Reset() {
+}
+
+func TestEd25519RawSign(t *testing.T) {
+	require := require.New(t)
+
+	seed := sha512.Sum512_256([]byte("oasis-runtime-sdk/test-keys: ed25519 raw"))
+	signer, ok := WrapSigner(&rawTestSigner{privateKey: ed25519.NewKeyFromSeed(seed[:])}).(sdkSignature.RawSigner)
+	require.True(ok, "signers natively supporting raw signing should be wrapped as a RawSigner")
+	pk := signer.Public().(PublicKey)
+	msg := []byte("message")
+
+	sig, err := signer.Sign(msg)
+	require.NoError(err, "Sign")
+	require.True(pk.VerifyRaw(msg, sig), "raw signature should verify")
+	require.True(ed25519.Verify(pk[:], msg, sig), "raw signature should verify with a plain Ed25519 verifier")
+	require.False(pk.VerifyRaw([]byte("other message"), sig), "raw signature should not verify for another message")
+	require.False(pk.Verify([]byte{}, msg, sig), "raw signature should not verify as a context signature")
+}
+
+func TestEd25519NewRawSigner(t *testing.T) {
+	require := require.New(t)
+
+	coreSigner, ok := memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: ed25519 unsafe raw").(coreSignature.UnsafeSigner)
+	require.True(ok, "memory signer should have extractable key material")
+	signer := NewRawSigner(coreSigner)
+	pk := signer.Public().(PublicKey)
+	msg := []byte("message")
+
+	sig, err := signer.Sign(msg)
+	require.NoError(err, "Sign")
+	require.True(pk.VerifyRaw(msg, sig), "raw signature should verify")
+	require.True(ed25519.Verify(pk[:], msg, sig), "raw signature should verify with a plain Ed25519 verifier")
+	require.False(pk.Verify([]byte{}, msg, sig), "raw signature should not verify as a context signature")
+
+	ctx := []byte("oasis-runtime-sdk/test: context")
+	sig, err = signer.ContextSign(ctx, msg)
+	require.NoError(err, "ContextSign")
+	require.True(pk.Verify(ctx, msg, sig), "context signature should verify")
+}
+
+func TestEd25519Equal(t *testing.T) {
+	require := require.New(t)
+
+	pkA := WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: ed25519 equal a")).Public()
+	pkB := WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: ed25519 equal b")).Public()
+
+	require.True(pkA.Equal(pkA), "public key should equal itself")
+	require.False(pkA.Equal(pkB), "different public keys should not be equal")
+}
+
+func TestEd25519SignMessage(t *testing.T) {
+	require := require.New(t)
+
+	signer := WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: ed25519 message"))
+	pk := signer.Public()
+	msg := []byte("hello")
+
+	sig, err := sdkSignature.SignMessage(signer, "my-dapp", msg)
+	require.NoError(err, "SignMessage")
+	require.True(sdkSignature.VerifyMessage(pk, "my-dapp", msg, sig), "message signature should verify")
+	require.False(sdkSignature.VerifyMessage(pk, "other-dapp", msg, sig), "message signature should not verify under another domain")
+	require.False(sdkSignature.VerifyMessage(pk, "my-dapp", []byte("other"), sig), "message signature should not verify for another message")
+	require.False(sdkSignature.VerifyMessage(pk, "", msg, sig), "message signature should not verify with an empty domain")
+
+	other := WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: ed25519 message other"))
+	require.False(sdkSignature.VerifyMessage(other.Public(), "my-dapp", msg, sig), "message signature should not verify under another key")
+
+	// A message signature must not be usable as a transaction signature.
+	var runtimeID common.Namespace
+	chainCtx := sdkSignature.DeriveChainContext(runtimeID, "0000000000000000000000000000000000000000000000000000000000000001")
+	txCtx := chainCtx.New([]byte("oasis-runtime-sdk/tx: v0"))
+	require.False(pk.Verify(txCtx, append([]byte("\x07my-dapp"), msg...), sig), "message signature should not verify as a transaction signature")
+}
@@ -1,11 +1,21 @@
 package ed25519
 
 import (
+	"crypto/ed25519"
+
 	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
 )
 
+// rawCoreSigner is an Oasis Core signer that natively supports signing raw messages.
+type rawCoreSigner interface {
+	coreSignature.Signer
+
+	// Sign generates a signature over the raw message without any domain separation.
+	Sign(message []byte) ([]byte, error)
+}
+
 type wrappedSigner struct {
 	signer coreSignature.Signer
 }
@@ -26,7 +36,48 @@ func (w wrappedSigner) Reset() {
 	w.signer.Reset()
 }
 
+type wrappedRawSigner struct {
+	wrappedSigner
+
+	raw rawCoreSigner
+}
+
+// Sign generates a raw Ed25519 signature over the message without any domain separation.
+func (w wrappedRawSigner) Sign(message []byte) ([]byte, error) {
+	return w.raw.Sign(message)
+}
+
 // WrapSigner wraps an Oasis Core Ed25519 signer.
+//
+// The returned signer produces context-domain-separated signatures via ContextSign (as required
+// by all on-chain flows, e.g., transaction authentication). In case the wrapped signer natively
+// supports signing raw messages (by implementing a Sign(message []byte) ([]byte, error) method),
+// the returned signer also implements the RawSigner interface for producing raw signatures for
+// external verifiers. Use NewRawSigner for signers with extractable key material.
 func WrapSigner(signer coreSignature.Signer) signature.Signer {
+	if raw, ok := signer.(rawCoreSigner); ok {
+		return wrappedRawSigner{wrappedSigner: wrappedSigner{signer: signer}, raw: raw}
+	}
 	return wrappedSigner{signer: signer}
 }
+
+type unsafeRawSigner struct {
+	wrappedSigner
+
+	unsafe coreSignature.UnsafeSigner
+}
+
+// Sign generates a raw Ed25519 signature over the message without any domain separation.
+func (w unsafeRawSigner) Sign(message []byte) ([]byte, error) {
+	return ed25519.Sign(ed25519.PrivateKey(w.unsafe.UnsafeBytes()), message), nil
+}
+
+// NewRawSigner wraps an Oasis Core Ed25519 signer so that it is also capable of producing raw
+// signatures for external verifiers.
+//
+// Raw signatures are generated from the private key returned by UnsafeBytes, so this requires
+// a signer with extractable key material (e.g., the memory or file signer). Signers backed by
+// hardware or plugins generally do not support this.
+func NewRawSigner(signer coreSignature.UnsafeSigner) signature.RawSigner {
+	return unsafeRawSigner{wrappedSigner: wrappedSigner{signer: signer}, unsafe: signer}
+}
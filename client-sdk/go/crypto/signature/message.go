@@ -0,0 +1,56 @@
+package signature
+
+import "fmt"
+
+const (
+	// MessageSignatureContext is the domain separation context used for signing arbitrary
+	// messages. It differs from the transaction signing context (which is always bound to a
+	// chain) so that a signed message can never be replayed as a transaction.
+	MessageSignatureContext = "oasis-runtime-sdk/message: v0"
+
+	// maxMessageDomainSize is the maximum size of a message domain tag.
+	maxMessageDomainSize = 255
+)
+
+// encodeMessage prepends the length-prefixed domain tag to the message.
+func encodeMessage(domain string, message []byte) ([]byte, error) {
+	switch {
+	case len(domain) == 0:
+		return nil, fmt.Errorf("signature: message domain must not be empty")
+	case len(domain) > maxMessageDomainSize:
+		return nil, fmt.Errorf("signature: message domain too long")
+	}
+
+	payload := make([]byte, 0, 1+len(domain)+len(message))
+	payload = append(payload, byte(len(domain)))
+	payload = append(payload, []byte(domain)...)
+	payload = append(payload, message...)
+	return payload, nil
+}
+
+// SignMessage signs an arbitrary message under the given application-specific domain (e.g., the
+// dApp name). See VerifyMessage for verification.
+//
+// The domain must be between 1 and 255 bytes long. The signed payload is formatted as:
+//
+//	len(domain) (1 byte) || domain || message
+//
+// and is signed under the MessageSignatureContext signature context. The length prefix ensures
+// that distinct (domain, message) pairs never encode to the same payload.
+func SignMessage(signer Signer, domain string, message []byte) ([]byte, error) {
+	payload, err := encodeMessage(domain, message)
+	if err != nil {
+		return nil, err
+	}
+	return signer.ContextSign([]byte(MessageSignatureContext), payload)
+}
+
+// VerifyMessage returns true iff the signature is a valid signature produced by SignMessage for
+// the given public key, domain and message.
+func VerifyMessage(pk PublicKey, domain string, message, signature []byte) bool {
+	payload, err := encodeMessage(domain, message)
+	if err != nil {
+		return false
+	}
+	return pk.Verify([]byte(MessageSignatureContext), payload, signature)
+}
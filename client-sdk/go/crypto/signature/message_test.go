@@ -0,0 +1,28 @@
+package signature
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignMessage(t *testing.T) {
+	require := require.New(t)
+
+	// The test signer returns the context and message as the signature.
+	sig, err := SignMessage(testSigner{}, "my-dapp", []byte("hello"))
+	require.NoError(err, "SignMessage")
+	require.EqualValues(MessageSignatureContext+"\x07my-dapphello", string(sig))
+
+	sigA, err := SignMessage(testSigner{}, "ab", []byte("c"))
+	require.NoError(err, "SignMessage")
+	sigB, err := SignMessage(testSigner{}, "a", []byte("bc"))
+	require.NoError(err, "SignMessage")
+	require.NotEqual(sigA, sigB, "different domains should produce different payloads")
+
+	_, err = SignMessage(testSigner{}, "", []byte("hello"))
+	require.Error(err, "SignMessage should fail with an empty domain")
+	_, err = SignMessage(testSigner{}, strings.Repeat("a", 256), []byte("hello"))
+	require.Error(err, "SignMessage should fail with a domain that is too long")
+}
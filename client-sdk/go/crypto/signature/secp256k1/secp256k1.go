@@ -1,6 +1,7 @@
 package secp256k1
 
 import (
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/json"
 
@@ -65,7 +66,7 @@ func (pk PublicKey) String() string {
 	return string(str)
 }
 
-// Equal compares vs another public key for equality.
+// Equal compares vs another public key for equality in constant time.
 func (pk PublicKey) Equal(other sdkSignature.PublicKey) bool {
 	opk, ok := other.(PublicKey)
 	if !ok {
@@ -73,7 +74,7 @@ func (pk PublicKey) Equal(other sdkSignature.PublicKey) bool {
 	}
 	obpk := btcec.PublicKey(opk)
 	bpk := btcec.PublicKey(pk)
-	return bpk.IsEqual(&obpk)
+	return subtle.ConstantTimeCompare(bpk.SerializeCompressed(), obpk.SerializeCompressed()) == 1
 }
 
 // Verify returns true iff the signature is valid for the public key over the context and message.
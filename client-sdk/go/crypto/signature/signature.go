@@ -7,6 +7,9 @@ type PublicKey interface {
 	String() string
 
 	// Equal compares vs another public key for equality.
+	//
+	// Implementations must compare public keys of the same scheme in constant time so that the
+	// comparison can be used in signer-matching logic without leaking timing information.
 	Equal(other PublicKey) bool
 
 	// Verify returns true iff the signature is valid for the public key over the context and
@@ -17,3 +17,16 @@ type Signer interface {
 	// Reset tears down the Signer and obliterates any sensitive state if any.
 	Reset()
 }
+
+// RawSigner is a Signer that is also capable of producing signatures over raw messages without
+// any domain separation.
+//
+// Raw signatures MUST NOT be used for anything that is verified on-chain (e.g., transaction
+// authentication always uses ContextSign with a chain domain separation context). They are only
+// provided for interoperability with external verifiers that expect plain signatures.
+type RawSigner interface {
+	Signer
+
+	// Sign generates a signature with the private key over the raw message.
+	Sign(message []byte) ([]byte, error)
+}
@@ -11,6 +11,7 @@ replace (
 
 require (
 	github.com/btcsuite/btcd v0.22.0-beta
+	github.com/fxamacker/cbor/v2 v2.2.1-0.20200820021930-bafca87fa6db
 	github.com/oasisprotocol/oasis-core/go v0.2102.5
 	github.com/stretchr/testify v1.7.0
 	golang.org/x/net v0.0.0-20210510120150-4163338589ed // indirect
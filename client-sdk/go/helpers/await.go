@@ -0,0 +1,87 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// SubmitAndAwaitEvent signs and submits the given transaction (see SignAndSubmit) and then waits
+// for an event emitted by the transaction, decoded using the given decoder, that satisfies the
+// given match function.
+//
+// Blocks are watched starting before the transaction is submitted so the resulting event cannot
+// be missed. Only events emitted by the submitted transaction (as identified by its hash) after
+// the latest round at the time of submission are matched, so events emitted by other
+// transactions are ignored. In case no matching event is observed within the given timeout, an
+// error is returned.
+func SubmitAndAwaitEvent(
+	ctx context.Context,
+	rc client.RuntimeClient,
+	signer signature.Signer,
+	tx *types.Transaction,
+	decoder client.EventDecoder,
+	match func(client.DecodedEvent) bool,
+	timeout time.Duration,
+) (client.DecodedEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	blkCh, blkSub, err := rc.WatchBlocks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to blocks: %w", err)
+	}
+	defer blkSub.Close()
+
+	latest, err := rc.GetBlock(ctx, client.RoundLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+	submitRound := latest.Header.Round
+
+	_, txHash, err := signAndSubmit(ctx, rc, signer, tx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for event: %w", ctx.Err())
+		case blk, ok := <-blkCh:
+			if !ok {
+				return nil, fmt.Errorf("block subscription closed")
+			}
+
+			round := blk.Block.Header.Round
+			if round <= submitRound {
+				continue
+			}
+			rawEvents, err := rc.GetEvents(ctx, round)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch events for round %d: %w", round, err)
+			}
+			var txEvents []*coreClient.Event
+			for _, rawEv := range rawEvents {
+				if rawEv.TxHash == txHash {
+					txEvents = append(txEvents, rawEv)
+				}
+			}
+			events, err := client.DecodeEvents(txEvents, []client.EventDecoder{decoder})
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode events for round %d: %w", round, err)
+			}
+			for _, ev := range events {
+				if match(ev) {
+					return ev, nil
+				}
+			}
+		}
+	}
+}
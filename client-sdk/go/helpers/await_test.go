@@ -0,0 +1,124 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// roundDecoder decodes events whose value is the round in which they were emitted.
+type roundDecoder struct{}
+
+// Implements client.EventDecoder.
+func (roundDecoder) DecodeEvent(ev *client.Event) ([]client.DecodedEvent, error) {
+	var round uint64
+	if err := cbor.Unmarshal(ev.Value, &round); err != nil {
+		return nil, err
+	}
+	return []client.DecodedEvent{round}, nil
+}
+
+// newAwaitTestClient creates a fake client for SubmitAndAwaitEvent tests. The latest round at
+// submission time is 10 and the block subscription delivers rounds 10 and 11. The given function
+// is used to generate the events emitted in each round given the hash of the submitted
+// transaction.
+func newAwaitTestClient(events func(round uint64, txHash hash.Hash) []*coreClient.Event) (*fakeClient, signature.Signer) {
+	var runtimeID common.Namespace
+	chainCtx := signature.DeriveChainContext(runtimeID, "0000000000000000000000000000000000000000000000000000000000000001")
+	signer := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: submit and await"))
+
+	newBlock := func(round uint64) *roothash.AnnotatedBlock {
+		var blk block.Block
+		blk.Header.Round = round
+		return &roothash.AnnotatedBlock{Block: &blk}
+	}
+
+	var txHash hash.Hash
+	fc := &fakeClient{
+		getInfo: func(ctx context.Context) (*types.RuntimeInfo, error) {
+			return &types.RuntimeInfo{ID: runtimeID, ChainContext: chainCtx}, nil
+		},
+		query: func(ctx context.Context, round uint64, method string, args interface{}) (interface{}, error) {
+			return uint64(0), nil
+		},
+		getBlock: func(ctx context.Context, round uint64) (*block.Block, error) {
+			return newBlock(10).Block, nil
+		},
+		submitTx: func(ctx context.Context, utx *types.UnverifiedTransaction) (cbor.RawMessage, error) {
+			txHash = hash.NewFromBytes(cbor.Marshal(utx))
+			return cbor.Marshal(nil), nil
+		},
+		watchBlocks: func(ctx context.Context) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+			// The subscription starts with the latest block, which predates the submission.
+			ch := make(chan *roothash.AnnotatedBlock, 2)
+			ch <- newBlock(10)
+			ch <- newBlock(11)
+			_, sub := pubsub.NewContextSubscription(ctx)
+			return ch, sub, nil
+		},
+		getEvents: func(ctx context.Context, round uint64) ([]*coreClient.Event, error) {
+			return events(round, txHash), nil
+		},
+	}
+	return fc, signer
+}
+
+// newRoundEvent creates an event emitted by the given transaction whose value is the round.
+func newRoundEvent(round uint64, txHash hash.Hash) *coreClient.Event {
+	return &coreClient.Event{Key: []byte("test\x00\x00\x00\x01"), Value: cbor.Marshal(round), TxHash: txHash}
+}
+
+func TestSubmitAndAwaitEventSkipsEarlierRounds(t *testing.T) {
+	require := require.New(t)
+
+	// Every round emits an event of the submitted transaction that satisfies the match function.
+	fc, signer := newAwaitTestClient(func(round uint64, txHash hash.Hash) []*coreClient.Event {
+		return []*coreClient.Event{newRoundEvent(round, txHash)}
+	})
+	tx := types.NewTransaction(&types.Fee{Gas: 1234}, "test.Foo", nil)
+
+	ev, err := SubmitAndAwaitEvent(context.Background(), fc, signer, tx, roundDecoder{}, func(client.DecodedEvent) bool {
+		return true
+	}, time.Second)
+	require.NoError(err)
+	require.EqualValues(11, ev, "events emitted before submission should not be matched")
+}
+
+func TestSubmitAndAwaitEventSkipsOtherTransactions(t *testing.T) {
+	require := require.New(t)
+
+	otherTxHash := hash.NewFromBytes([]byte("other transaction"))
+	fc, signer := newAwaitTestClient(func(round uint64, txHash hash.Hash) []*coreClient.Event {
+		if round != 11 {
+			return nil
+		}
+		// A matching event emitted by another transaction comes first.
+		return []*coreClient.Event{
+			newRoundEvent(100, otherTxHash),
+			newRoundEvent(round, txHash),
+		}
+	})
+	tx := types.NewTransaction(&types.Fee{Gas: 1234}, "test.Foo", nil)
+
+	ev, err := SubmitAndAwaitEvent(context.Background(), fc, signer, tx, roundDecoder{}, func(client.DecodedEvent) bool {
+		return true
+	}, time.Second)
+	require.NoError(err)
+	require.EqualValues(11, ev, "events emitted by other transactions should not be matched")
+}
@@ -0,0 +1,138 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// DefaultNonceSyncInterval is the default interval after which the nonce manager reconciles its
+// local nonce with the chain.
+const DefaultNonceSyncInterval = 30 * time.Second
+
+// NonceManager hands out monotonically increasing nonces for a single account, enabling
+// concurrent pipelining of transactions without manual nonce coordination.
+//
+// Nonces released via Rollback are kept in a gap list and handed out again before any new
+// nonces so that a failed submission does not stall the transactions that follow it.
+//
+// The local nonce is periodically reconciled with the nonce reported by the chain. In case the
+// chain is ahead (e.g., because transactions were submitted by someone else), the local nonce
+// is advanced accordingly.
+type NonceManager struct {
+	l sync.Mutex
+
+	accounts accounts.V1
+	address  types.Address
+
+	next         uint64
+	gaps         []uint64
+	synced       bool
+	lastSync     time.Time
+	syncInterval time.Duration
+}
+
+func (nm *NonceManager) sync(ctx context.Context) error {
+	nonce, err := nm.accounts.Nonce(ctx, client.RoundLatest, nm.address)
+	if err != nil {
+		return fmt.Errorf("failed to query account nonce: %w", err)
+	}
+
+	if !nm.synced || nonce > nm.next {
+		nm.next = nonce
+	}
+	// Drop any released nonces that have already been used on chain.
+	gaps := nm.gaps[:0]
+	for _, gap := range nm.gaps {
+		if gap >= nonce && gap < nm.next {
+			gaps = append(gaps, gap)
+		}
+	}
+	nm.gaps = gaps
+	nm.synced = true
+	nm.lastSync = time.Now()
+	return nil
+}
+
+// Next returns the next nonce that should be used for a transaction signed by the account.
+func (nm *NonceManager) Next(ctx context.Context) (uint64, error) {
+	nm.l.Lock()
+	defer nm.l.Unlock()
+
+	if !nm.synced || time.Since(nm.lastSync) >= nm.syncInterval {
+		if err := nm.sync(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(nm.gaps) > 0 {
+		nonce := nm.gaps[0]
+		nm.gaps = nm.gaps[1:]
+		return nonce, nil
+	}
+
+	nonce := nm.next
+	nm.next++
+	return nonce, nil
+}
+
+// Rollback notifies the nonce manager that the transaction using the given nonce failed to be
+// submitted so the nonce has not been used. The nonce will be handed out again before any new
+// nonces.
+func (nm *NonceManager) Rollback(nonce uint64) {
+	nm.l.Lock()
+	defer nm.l.Unlock()
+
+	if !nm.synced || nonce >= nm.next {
+		return
+	}
+
+	// Insert into the sorted gap list, ignoring duplicates.
+	idx := sort.Search(len(nm.gaps), func(i int) bool { return nm.gaps[i] >= nonce })
+	if idx < len(nm.gaps) && nm.gaps[idx] == nonce {
+		return
+	}
+	nm.gaps = append(nm.gaps, 0)
+	copy(nm.gaps[idx+1:], nm.gaps[idx:])
+	nm.gaps[idx] = nonce
+
+	// Collapse any trailing gaps into the next nonce.
+	for len(nm.gaps) > 0 && nm.gaps[len(nm.gaps)-1]+1 == nm.next {
+		nm.next--
+		nm.gaps = nm.gaps[:len(nm.gaps)-1]
+	}
+}
+
+// Reset forces the nonce manager to resynchronize with the chain on next use.
+func (nm *NonceManager) Reset() {
+	nm.l.Lock()
+	defer nm.l.Unlock()
+
+	nm.synced = false
+	nm.gaps = nil
+}
+
+// SetSyncInterval configures the interval after which the local nonce is reconciled with the
+// chain.
+func (nm *NonceManager) SetSyncInterval(interval time.Duration) *NonceManager {
+	nm.l.Lock()
+	defer nm.l.Unlock()
+
+	nm.syncInterval = interval
+	return nm
+}
+
+// NewNonceManager creates a new nonce manager for the given account.
+func NewNonceManager(rc client.RuntimeClient, address types.Address) *NonceManager {
+	return &NonceManager{
+		accounts:     accounts.NewV1(rc),
+		address:      address,
+		syncInterval: DefaultNonceSyncInterval,
+	}
+}
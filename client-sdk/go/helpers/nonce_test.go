@@ -0,0 +1,125 @@
+package helpers
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func newNonceTestClient(chainNonce *uint64, l *sync.Mutex) *fakeClient {
+	return &fakeClient{
+		query: func(ctx context.Context, round uint64, method string, args interface{}) (interface{}, error) {
+			l.Lock()
+			defer l.Unlock()
+			return *chainNonce, nil
+		},
+	}
+}
+
+func TestNonceManagerRollback(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		l          sync.Mutex
+		chainNonce uint64 = 5
+	)
+	nm := NewNonceManager(newNonceTestClient(&chainNonce, &l), types.Address{})
+	ctx := context.Background()
+
+	for i := uint64(5); i < 9; i++ {
+		nonce, err := nm.Next(ctx)
+		require.NoError(err)
+		require.EqualValues(i, nonce)
+	}
+
+	// Rolling back a nonce in the middle must not move the local nonce backwards.
+	nm.Rollback(6)
+	nonce, err := nm.Next(ctx)
+	require.NoError(err)
+	require.EqualValues(6, nonce, "released nonce should be reused first")
+	nonce, err = nm.Next(ctx)
+	require.NoError(err)
+	require.EqualValues(9, nonce, "new nonces should continue after the last one handed out")
+
+	// Rolling back the trailing nonces collapses them.
+	nm.Rollback(8)
+	nm.Rollback(9)
+	nm.Rollback(9)
+	nonce, err = nm.Next(ctx)
+	require.NoError(err)
+	require.EqualValues(8, nonce)
+	nonce, err = nm.Next(ctx)
+	require.NoError(err)
+	require.EqualValues(9, nonce)
+
+	// Released nonces that were used on chain in the meantime are dropped on sync.
+	nm.Rollback(7)
+	l.Lock()
+	chainNonce = 12
+	l.Unlock()
+	nm.Reset()
+	nonce, err = nm.Next(ctx)
+	require.NoError(err)
+	require.EqualValues(12, nonce, "local nonce should be advanced to the chain nonce")
+}
+
+func TestNonceManagerConcurrent(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		l          sync.Mutex
+		chainNonce uint64 = 100
+	)
+	nm := NewNonceManager(newNonceTestClient(&chainNonce, &l), types.Address{})
+	ctx := context.Background()
+
+	const (
+		workers   = 8
+		perWorker = 50
+	)
+	var (
+		wg     sync.WaitGroup
+		seenMu sync.Mutex
+		seen   = make(map[uint64]int)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				nonce, err := nm.Next(ctx)
+				if err != nil {
+					t.Errorf("failed to get nonce: %s", err)
+					return
+				}
+				// Every third transaction of each worker fails and releases its nonce.
+				if i%3 == 0 {
+					nm.Rollback(nonce)
+					continue
+				}
+				seenMu.Lock()
+				seen[nonce]++
+				seenMu.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	// All used nonces must be unique and together with the released nonces must cover the whole
+	// range of handed out nonces.
+	for nonce, count := range seen {
+		require.Equal(1, count, "nonce %d handed out multiple times", nonce)
+	}
+	for _, gap := range nm.gaps {
+		require.NotContains(seen, gap, "released nonce %d was used", gap)
+		seen[gap]++
+	}
+	require.EqualValues(nm.next-chainNonce, len(seen))
+	for nonce := chainNonce; nonce < nm.next; nonce++ {
+		require.Contains(seen, nonce, "nonce %d should have been handed out", nonce)
+	}
+}
@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// TransactionSequence is a helper for composing several calls that should be executed in order
+// by the same signer.
+//
+// As the runtime does not support batching multiple calls into a single transaction, each call
+// is submitted as a separate transaction and the next transaction is only submitted after the
+// previous one has been executed successfully. Nonces are handled automatically.
+type TransactionSequence struct {
+	rc  client.RuntimeClient
+	txs []*types.Transaction
+}
+
+// NewTransactionSequence creates a new empty transaction sequence.
+func NewTransactionSequence(rc client.RuntimeClient) *TransactionSequence {
+	return &TransactionSequence{rc: rc}
+}
+
+// Call appends a call of the given method with the given body to the sequence.
+func (ts *TransactionSequence) Call(method string, body interface{}) *TransactionSequence {
+	ts.txs = append(ts.txs, types.NewTransaction(nil, method, body))
+	return ts
+}
+
+// Then appends the transaction from the given transaction builder (e.g., as returned by a module
+// client) to the sequence.
+func (ts *TransactionSequence) Then(tb *client.TransactionBuilder) *TransactionSequence {
+	ts.txs = append(ts.txs, tb.GetTransaction())
+	return ts
+}
+
+// Transactions returns the unsigned transactions in the sequence.
+func (ts *TransactionSequence) Transactions() []*types.Transaction {
+	return ts.txs
+}
+
+// SignAndSubmit signs and submits all transactions in the sequence in order, waiting for each
+// transaction to be executed before submitting the next one.
+//
+// Submission stops at the first failed transaction in which case the results of all previously
+// executed transactions are returned together with the error.
+func (ts *TransactionSequence) SignAndSubmit(
+	ctx context.Context,
+	signer signature.Signer,
+	opts *SignAndSubmitOptions,
+) ([]cbor.RawMessage, error) {
+	results := make([]cbor.RawMessage, 0, len(ts.txs))
+	for i, tx := range ts.txs {
+		result, err := SignAndSubmit(ctx, ts.rc, signer, tx, opts)
+		if err != nil {
+			return results, fmt.Errorf("call %d (%s) failed: %w", i, tx.Call.Method, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,141 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/core"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// DefaultEstimationGasLimit is the default gas limit used when estimating gas.
+const DefaultEstimationGasLimit = 1_000_000
+
+// SignAndSubmitOptions are options for SignAndSubmit.
+type SignAndSubmitOptions struct {
+	// SkipGasEstimation disables gas estimation and uses the gas limit specified in the
+	// transaction instead.
+	SkipGasEstimation bool
+	// EstimationGasLimit is the gas limit used while estimating gas. In case it is zero,
+	// DefaultEstimationGasLimit is used.
+	EstimationGasLimit uint64
+	// FallbackOnEstimationFailure makes gas estimation failures non-fatal. In case estimation
+	// fails, the gas limit specified in the transaction is used instead.
+	FallbackOnEstimationFailure bool
+	// Confirmations is the number of subsequent rounds that must be observed on top of the
+	// transaction's inclusion round before returning. In case it is zero, the call returns as
+	// soon as the transaction has been executed.
+	//
+	// Note that waiting for confirmations adds latency of roughly one runtime round per
+	// confirmation. As the inclusion round is not reported on submission, it is determined by
+	// looking up the transaction in the blocks produced while it was being submitted.
+	Confirmations uint64
+}
+
+// SignAndSubmit fetches the signer's nonce, estimates gas, signs the given transaction with the
+// given signer and submits it to the runtime transaction scheduler, waiting for transaction
+// execution results.
+//
+// The signer is appended as a new signer to a copy of the transaction, so the passed
+// transaction is not modified.
+func SignAndSubmit(
+	ctx context.Context,
+	rc client.RuntimeClient,
+	signer signature.Signer,
+	tx *types.Transaction,
+	opts *SignAndSubmitOptions,
+) (cbor.RawMessage, error) {
+	result, _, err := signAndSubmit(ctx, rc, signer, tx, opts)
+	return result, err
+}
+
+// signAndSubmit implements SignAndSubmit and additionally returns the hash of the submitted
+// transaction.
+func signAndSubmit(
+	ctx context.Context,
+	rc client.RuntimeClient,
+	signer signature.Signer,
+	tx *types.Transaction,
+	opts *SignAndSubmitOptions,
+) (cbor.RawMessage, hash.Hash, error) {
+	if opts == nil {
+		opts = &SignAndSubmitOptions{}
+	}
+
+	rtInfo, err := rc.GetInfo(ctx)
+	if err != nil {
+		return nil, hash.Hash{}, fmt.Errorf("failed to retrieve runtime info: %w", err)
+	}
+
+	nonce, err := accounts.NewV1(rc).Nonce(ctx, client.RoundLatest, types.NewAddress(signer.Public()))
+	if err != nil {
+		return nil, hash.Hash{}, fmt.Errorf("failed to query account nonce: %w", err)
+	}
+
+	etx := *tx
+	etx.AuthInfo.SignerInfo = append([]types.SignerInfo{}, tx.AuthInfo.SignerInfo...)
+	etx.AppendAuthSignature(signer.Public(), nonce)
+
+	if !opts.SkipGasEstimation {
+		etx.AuthInfo.Fee.Gas = opts.EstimationGasLimit
+		if etx.AuthInfo.Fee.Gas == 0 {
+			etx.AuthInfo.Fee.Gas = DefaultEstimationGasLimit
+		}
+
+		gas, err := core.NewV1(rc).EstimateGas(ctx, client.RoundLatest, &etx)
+		switch {
+		case err == nil:
+			etx.AuthInfo.Fee.Gas = gas
+		case opts.FallbackOnEstimationFailure:
+			etx.AuthInfo.Fee.Gas = tx.AuthInfo.Fee.Gas
+		default:
+			return nil, hash.Hash{}, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+	}
+
+	ts := etx.PrepareForSigning()
+	if err = ts.AppendSign(rtInfo.ChainContext, signer); err != nil {
+		return nil, hash.Hash{}, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	utx := ts.UnverifiedTransaction()
+	txHash := hash.NewFromBytes(cbor.Marshal(utx))
+
+	var fromRound uint64
+	if opts.Confirmations > 0 {
+		blk, err := rc.GetBlock(ctx, client.RoundLatest)
+		if err != nil {
+			return nil, txHash, fmt.Errorf("failed to fetch latest block: %w", err)
+		}
+		// The transaction can only be included in a subsequent round.
+		fromRound = blk.Header.Round + 1
+	}
+
+	result, err := rc.SubmitTx(ctx, utx)
+	if err != nil {
+		return nil, txHash, err
+	}
+
+	if opts.Confirmations > 0 {
+		blk, err := rc.GetBlock(ctx, client.RoundLatest)
+		if err != nil {
+			return nil, txHash, fmt.Errorf("failed to fetch latest block: %w", err)
+		}
+		inclusionRound, found, err := client.FindTransactionRound(ctx, rc, txHash, fromRound, blk.Header.Round)
+		if err != nil {
+			return nil, txHash, fmt.Errorf("failed to determine inclusion round: %w", err)
+		}
+		if !found {
+			return nil, txHash, fmt.Errorf("failed to determine inclusion round: transaction not found in rounds %d-%d", fromRound, blk.Header.Round)
+		}
+		if _, err = rc.WaitForRound(ctx, inclusionRound+opts.Confirmations); err != nil {
+			return nil, txHash, fmt.Errorf("failed to wait for confirmations: %w", err)
+		}
+	}
+	return result, txHash, nil
+}
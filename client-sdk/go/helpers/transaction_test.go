@@ -0,0 +1,202 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// fakeClient is a RuntimeClient used in tests. Only the methods with a configured handler may be
+// called, calling any other method panics.
+type fakeClient struct {
+	client.RuntimeClient
+
+	getInfo         func(ctx context.Context) (*types.RuntimeInfo, error)
+	query           func(ctx context.Context, round uint64, method string, args interface{}) (interface{}, error)
+	getBlock        func(ctx context.Context, round uint64) (*block.Block, error)
+	getTransactions func(ctx context.Context, round uint64) ([]*types.UnverifiedTransaction, error)
+	submitTx        func(ctx context.Context, tx *types.UnverifiedTransaction) (cbor.RawMessage, error)
+	waitForRound    func(ctx context.Context, round uint64) (*block.Block, error)
+	watchBlocks     func(ctx context.Context) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error)
+	getEvents       func(ctx context.Context, round uint64) ([]*coreClient.Event, error)
+}
+
+// Implements client.RuntimeClient.
+func (fc *fakeClient) GetInfo(ctx context.Context) (*types.RuntimeInfo, error) {
+	if fc.getInfo == nil {
+		return nil, fmt.Errorf("fake: get info not supported")
+	}
+	return fc.getInfo(ctx)
+}
+
+// Implements client.RuntimeClient.
+func (fc *fakeClient) Query(ctx context.Context, round uint64, method string, args, rsp interface{}) error {
+	if fc.query == nil {
+		return fmt.Errorf("fake: query not supported")
+	}
+	result, err := fc.query(ctx, round, method, args)
+	if err != nil {
+		return err
+	}
+	return cbor.Unmarshal(cbor.Marshal(result), rsp)
+}
+
+// Implements client.RuntimeClient.
+func (fc *fakeClient) GetBlock(ctx context.Context, round uint64) (*block.Block, error) {
+	if fc.getBlock == nil {
+		return nil, fmt.Errorf("fake: get block not supported")
+	}
+	return fc.getBlock(ctx, round)
+}
+
+// Implements client.RuntimeClient.
+func (fc *fakeClient) GetTransactions(ctx context.Context, round uint64) ([]*types.UnverifiedTransaction, error) {
+	if fc.getTransactions == nil {
+		return nil, fmt.Errorf("fake: get transactions not supported")
+	}
+	return fc.getTransactions(ctx, round)
+}
+
+// Implements client.RuntimeClient.
+func (fc *fakeClient) SubmitTx(ctx context.Context, tx *types.UnverifiedTransaction) (cbor.RawMessage, error) {
+	if fc.submitTx == nil {
+		return nil, fmt.Errorf("fake: submit tx not supported")
+	}
+	return fc.submitTx(ctx, tx)
+}
+
+// Implements client.RuntimeClient.
+func (fc *fakeClient) WaitForRound(ctx context.Context, round uint64) (*block.Block, error) {
+	if fc.waitForRound == nil {
+		return nil, fmt.Errorf("fake: wait for round not supported")
+	}
+	return fc.waitForRound(ctx, round)
+}
+
+// Implements client.RuntimeClient.
+func (fc *fakeClient) WatchBlocks(ctx context.Context) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	if fc.watchBlocks == nil {
+		return nil, nil, fmt.Errorf("fake: watch blocks not supported")
+	}
+	return fc.watchBlocks(ctx)
+}
+
+// Implements client.RuntimeClient.
+func (fc *fakeClient) GetEvents(ctx context.Context, round uint64) ([]*coreClient.Event, error) {
+	if fc.getEvents == nil {
+		return nil, fmt.Errorf("fake: get events not supported")
+	}
+	return fc.getEvents(ctx, round)
+}
+
+func TestSignAndSubmitEstimationFailure(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	chainCtx := signature.DeriveChainContext(runtimeID, "0000000000000000000000000000000000000000000000000000000000000001")
+	signer := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: sign and submit"))
+
+	var submittedGas uint64
+	fc := &fakeClient{
+		getInfo: func(ctx context.Context) (*types.RuntimeInfo, error) {
+			return &types.RuntimeInfo{ID: runtimeID, ChainContext: chainCtx}, nil
+		},
+		query: func(ctx context.Context, round uint64, method string, args interface{}) (interface{}, error) {
+			switch method {
+			case "accounts.Nonce":
+				return uint64(0), nil
+			case "core.EstimateGas":
+				return nil, fmt.Errorf("estimation failed")
+			default:
+				return nil, fmt.Errorf("unsupported method: %s", method)
+			}
+		},
+		submitTx: func(ctx context.Context, utx *types.UnverifiedTransaction) (cbor.RawMessage, error) {
+			tx, err := utx.Verify(chainCtx)
+			if err != nil {
+				return nil, err
+			}
+			submittedGas = tx.AuthInfo.Fee.Gas
+			return cbor.Marshal(nil), nil
+		},
+	}
+	ctx := context.Background()
+	tx := types.NewTransaction(&types.Fee{Gas: 1234}, "test.Foo", nil)
+
+	_, err := SignAndSubmit(ctx, fc, signer, tx, nil)
+	require.Error(err, "estimation failure should be fatal by default")
+
+	_, err = SignAndSubmit(ctx, fc, signer, tx, &SignAndSubmitOptions{FallbackOnEstimationFailure: true})
+	require.NoError(err)
+	require.EqualValues(1234, submittedGas, "transaction gas limit should be used on estimation failure")
+}
+
+func TestSignAndSubmitConfirmations(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	chainCtx := signature.DeriveChainContext(runtimeID, "0000000000000000000000000000000000000000000000000000000000000001")
+	signer := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: sign and submit"))
+
+	var (
+		latestRound uint64 = 10
+		included    *types.UnverifiedTransaction
+		waitedFor   uint64
+	)
+	fc := &fakeClient{
+		getInfo: func(ctx context.Context) (*types.RuntimeInfo, error) {
+			return &types.RuntimeInfo{ID: runtimeID, ChainContext: chainCtx}, nil
+		},
+		query: func(ctx context.Context, round uint64, method string, args interface{}) (interface{}, error) {
+			return uint64(0), nil
+		},
+		getBlock: func(ctx context.Context, round uint64) (*block.Block, error) {
+			var blk block.Block
+			blk.Header.Round = latestRound
+			return &blk, nil
+		},
+		getTransactions: func(ctx context.Context, round uint64) ([]*types.UnverifiedTransaction, error) {
+			if round == 12 {
+				return []*types.UnverifiedTransaction{included}, nil
+			}
+			return nil, nil
+		},
+		submitTx: func(ctx context.Context, utx *types.UnverifiedTransaction) (cbor.RawMessage, error) {
+			// The transaction is included in round 12 but the result is only received after
+			// round 13 has been finalized.
+			included = utx
+			latestRound = 13
+			return cbor.Marshal(nil), nil
+		},
+		waitForRound: func(ctx context.Context, round uint64) (*block.Block, error) {
+			waitedFor = round
+			var blk block.Block
+			blk.Header.Round = round
+			return &blk, nil
+		},
+	}
+	ctx := context.Background()
+	tx := types.NewTransaction(&types.Fee{Gas: 1234}, "test.Foo", nil)
+
+	_, err := SignAndSubmit(ctx, fc, signer, tx, &SignAndSubmitOptions{
+		SkipGasEstimation: true,
+		Confirmations:     2,
+	})
+	require.NoError(err)
+	require.EqualValues(14, waitedFor, "confirmations should be counted from the inclusion round")
+}
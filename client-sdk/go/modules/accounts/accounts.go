@@ -2,18 +2,61 @@ package accounts
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
 
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 )
 
+// ModuleName is the accounts module name.
+const ModuleName = "accounts"
+
+// AddressesPageSize is the number of addresses fetched per query by Addresses.
+const AddressesPageSize = 1000
+
 const (
 	// Callable methods.
 	methodTransfer = "accounts.Transfer"
 
 	// Queries.
-	methodNonce    = "accounts.Nonce"
-	methodBalances = "accounts.Balances"
+	methodNonce         = "accounts.Nonce"
+	methodBalances      = "accounts.Balances"
+	methodAddresses     = "accounts.Addresses"
+	methodTotalSupplies = "accounts.TotalSupplies"
+)
+
+// ErrUnsupported is the error returned when the runtime does not support the requested feature.
+// The concrete error returned is an *UnsupportedError which matches ErrUnsupported when using
+// errors.Is.
+var ErrUnsupported = errors.New("accounts: feature not supported by runtime")
+
+// UnsupportedError is the error returned when the runtime does not support the requested feature.
+type UnsupportedError struct {
+	// Feature is the name of the unsupported feature.
+	Feature string
+}
+
+// Error returns the string representation of the error.
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrUnsupported, e.Feature)
+}
+
+// Is returns true iff the target is ErrUnsupported.
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupported
+}
+
+var (
+	// CommonPoolAddress is the address of the runtime's common pool account.
+	CommonPoolAddress = types.NewAddressForModule(ModuleName, "common-pool")
+	// FeeAccumulatorAddress is the address of the account accumulating the fees paid in the
+	// current round before they are disbursed at the end of the next round.
+	FeeAccumulatorAddress = types.NewAddressForModule(ModuleName, "fee-accumulator")
 )
 
 // V1 is the v1 accounts module interface.
@@ -21,15 +64,66 @@ type V1 interface {
 	// Transfer generates an accounts.Transfer transaction.
 	Transfer(to types.Address, amount types.BaseUnits) *client.TransactionBuilder
 
+	// TransferToMultisig generates an accounts.Transfer transaction to a multisig address after
+	// validating the given multisig configuration and verifying that the target address is the
+	// one derived from it.
+	TransferToMultisig(to types.Address, config *types.MultisigConfig, amount types.BaseUnits) (*client.TransactionBuilder, error)
+
 	// Nonce queries the given account's nonce.
+	//
+	// The query is performed against the state as of the given round, so passing a historical
+	// round (that has not yet been pruned) returns the account's nonce at that round.
 	Nonce(ctx context.Context, round uint64, address types.Address) (uint64, error)
 
 	// Balances queries the given account's balances.
 	Balances(ctx context.Context, round uint64, address types.Address) (*AccountBalances, error)
+
+	// HeldDenominations returns the sorted list of denominations in which the given account has
+	// a non-zero balance.
+	HeldDenominations(ctx context.Context, round uint64, address types.Address) ([]types.Denomination, error)
+
+	// Addresses queries all account addresses holding the given denomination.
+	//
+	// The addresses are fetched in pages of AddressesPageSize addresses.
+	Addresses(ctx context.Context, round uint64, denom types.Denomination) ([]types.Address, error)
+
+	// AddressesPage queries at most limit account addresses holding the given denomination that
+	// come strictly after the given address (or from the start in case it is nil). Addresses are
+	// ordered by their canonical binary representation so the last address of a page can be used
+	// to fetch the next one.
+	AddressesPage(ctx context.Context, round uint64, denom types.Denomination, after *types.Address, limit uint64) ([]types.Address, error)
+
+	// TotalSupplies queries the total supplies of all denominations.
+	TotalSupplies(ctx context.Context, round uint64) (map[types.Denomination]types.Quantity, error)
+
+	// VerifySupplyInvariant verifies that the sum of all account balances of the given
+	// denomination matches its total supply.
+	VerifySupplyInvariant(ctx context.Context, round uint64, denom types.Denomination) (*SupplyCheck, error)
+
+	// VestingSchedule queries the given account's vesting schedule, describing how its locked
+	// and unlocked balances change over time.
+	//
+	// The accounts module in this version of the SDK does not support vesting (there is no
+	// runtime query for it), so an error matching ErrUnsupported is always returned.
+	VestingSchedule(ctx context.Context, round uint64, address types.Address) (*VestingSchedule, error)
+
+	// WatchBalance streams the given account's balance of the given denomination.
+	//
+	// The current balance is emitted first, followed by the recomputed balance whenever a
+	// transfer, mint or burn event affecting the account is emitted. Balances reflect committed
+	// state only, pending transactions are not taken into account. In case fetching events or
+	// balances fails, the error is sent over the error channel. Both channels are closed once the
+	// watch stops.
+	WatchBalance(ctx context.Context, address types.Address, denom types.Denomination) (<-chan *BalanceUpdate, <-chan error, error)
+
+	// DecodeEvent decodes an accounts event.
+	DecodeEvent(event *client.Event) ([]client.DecodedEvent, error)
 }
 
 type v1 struct {
 	rc client.RuntimeClient
+
+	strictEvents bool
 }
 
 // Implements V1.
@@ -40,6 +134,17 @@ func (a *v1) Transfer(to types.Address, amount types.BaseUnits) *client.Transact
 	})
 }
 
+// Implements V1.
+func (a *v1) TransferToMultisig(to types.Address, config *types.MultisigConfig, amount types.BaseUnits) (*client.TransactionBuilder, error) {
+	if err := config.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid multisig configuration: %w", err)
+	}
+	if expected := types.NewAddressFromMultisig(config); !expected.Equal(to) {
+		return nil, fmt.Errorf("target address %s does not match multisig configuration (expected: %s)", to, expected)
+	}
+	return a.Transfer(to, amount), nil
+}
+
 // Implements V1.
 func (a *v1) Nonce(ctx context.Context, round uint64, address types.Address) (uint64, error) {
 	var nonce uint64
@@ -60,11 +165,291 @@ func (a *v1) Balances(ctx context.Context, round uint64, address types.Address)
 	return &balances, nil
 }
 
+// Implements V1.
+func (a *v1) HeldDenominations(ctx context.Context, round uint64, address types.Address) ([]types.Denomination, error) {
+	balances, err := a.Balances(ctx, round, address)
+	if err != nil {
+		return nil, err
+	}
+
+	denoms := make([]types.Denomination, 0, len(balances.Balances))
+	for denom, amount := range balances.Balances {
+		if amount.IsZero() {
+			continue
+		}
+		denoms = append(denoms, denom)
+	}
+	sort.Slice(denoms, func(i, j int) bool {
+		return denoms[i] < denoms[j]
+	})
+	return denoms, nil
+}
+
+// Implements V1.
+func (a *v1) Addresses(ctx context.Context, round uint64, denom types.Denomination) ([]types.Address, error) {
+	// Make sure all pages are fetched from the same round.
+	if round == client.RoundLatest {
+		blk, err := a.rc.GetBlock(ctx, round)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch block: %w", err)
+		}
+		round = blk.Header.Round
+	}
+
+	var (
+		addresses []types.Address
+		after     *types.Address
+	)
+	for {
+		page, err := a.AddressesPage(ctx, round, denom, after, AddressesPageSize)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, page...)
+		if len(page) < AddressesPageSize {
+			return addresses, nil
+		}
+		after = &page[len(page)-1]
+	}
+}
+
+// Implements V1.
+func (a *v1) AddressesPage(ctx context.Context, round uint64, denom types.Denomination, after *types.Address, limit uint64) ([]types.Address, error) {
+	var addresses []types.Address
+	err := a.rc.Query(ctx, round, methodAddresses, &AddressesQuery{
+		Denomination: denom,
+		After:        after,
+		Limit:        limit,
+	}, &addresses)
+	if err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// Implements V1.
+func (a *v1) VestingSchedule(ctx context.Context, round uint64, address types.Address) (*VestingSchedule, error) {
+	return nil, &UnsupportedError{Feature: "vesting schedules"}
+}
+
+// Implements V1.
+func (a *v1) TotalSupplies(ctx context.Context, round uint64) (map[types.Denomination]types.Quantity, error) {
+	var totalSupplies map[types.Denomination]types.Quantity
+	err := a.rc.Query(ctx, round, methodTotalSupplies, nil, &totalSupplies)
+	if err != nil {
+		return nil, err
+	}
+	return totalSupplies, nil
+}
+
+// Implements V1.
+func (a *v1) VerifySupplyInvariant(ctx context.Context, round uint64, denom types.Denomination) (*SupplyCheck, error) {
+	// Make sure all queries are performed against the same round.
+	blk, err := a.rc.GetBlock(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block: %w", err)
+	}
+	round = blk.Header.Round
+
+	check := SupplyCheck{
+		Round:        round,
+		Denomination: denom,
+	}
+
+	totalSupplies, err := a.TotalSupplies(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query total supplies: %w", err)
+	}
+	if ts, ok := totalSupplies[denom]; ok {
+		check.TotalSupply = ts
+	}
+
+	addresses, err := a.Addresses(ctx, round, denom)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query addresses: %w", err)
+	}
+	for _, addr := range addresses {
+		balances, err := a.Balances(ctx, round, addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query balances of %s: %w", addr, err)
+		}
+		if b, ok := balances.Balances[denom]; ok {
+			if err = check.ComputedSupply.Add(&b); err != nil {
+				return nil, fmt.Errorf("failed to compute supply: %w", err)
+			}
+		}
+	}
+
+	return &check, nil
+}
+
+// Implements V1.
+func (a *v1) WatchBalance(ctx context.Context, address types.Address, denom types.Denomination) (<-chan *BalanceUpdate, <-chan error, error) {
+	// Subscribe to blocks before querying the initial balance so no updates are missed.
+	blkCh, blkSub, err := a.rc.WatchBlocks(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	balance := func(round uint64) (*BalanceUpdate, error) {
+		balances, err := a.Balances(ctx, round, address)
+		if err != nil {
+			return nil, err
+		}
+		update := BalanceUpdate{Round: round}
+		if b, ok := balances.Balances[denom]; ok {
+			update.Balance = b
+		}
+		return &update, nil
+	}
+
+	blk, err := a.rc.GetBlock(ctx, client.RoundLatest)
+	if err != nil {
+		blkSub.Close()
+		return nil, nil, err
+	}
+	initial, err := balance(blk.Header.Round)
+	if err != nil {
+		blkSub.Close()
+		return nil, nil, err
+	}
+
+	ch := make(chan *BalanceUpdate)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		defer close(errCh)
+		defer blkSub.Close()
+
+		send := func(update *BalanceUpdate) bool {
+			select {
+			case ch <- update:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		if !send(initial) {
+			return
+		}
+
+		lastRound := initial.Round
+		for {
+			var annBlk *roothash.AnnotatedBlock
+			select {
+			case annBlk = <-blkCh:
+			case <-ctx.Done():
+				return
+			}
+			if annBlk == nil {
+				return
+			}
+
+			round := annBlk.Block.Header.Round
+			if round <= lastRound {
+				continue
+			}
+			lastRound = round
+
+			rawEvents, err := a.rc.GetEvents(ctx, round)
+			if err != nil {
+				errCh <- fmt.Errorf("failed to fetch events for round %d: %w", round, err)
+				return
+			}
+			if !a.affectsBalance(rawEvents, address, denom) {
+				continue
+			}
+
+			update, err := balance(round)
+			if err != nil {
+				errCh <- fmt.Errorf("failed to fetch balance for round %d: %w", round, err)
+				return
+			}
+			if !send(update) {
+				return
+			}
+		}
+	}()
+
+	return ch, errCh, nil
+}
+
+// affectsBalance checks whether any of the given events affect the balance of the given account
+// for the given denomination.
+func (a *v1) affectsBalance(rawEvents []*coreClient.Event, address types.Address, denom types.Denomination) bool {
+	events, err := client.DecodeEvents(rawEvents, []client.EventDecoder{a})
+	if err != nil {
+		// Be conservative and assume the balance could have changed.
+		return true
+	}
+
+	for _, ev := range events {
+		ae := ev.(*Event)
+		switch {
+		case ae.Transfer != nil:
+			if ae.Transfer.Amount.Denomination == denom && (ae.Transfer.From.Equal(address) || ae.Transfer.To.Equal(address)) {
+				return true
+			}
+		case ae.Burn != nil:
+			if ae.Burn.Amount.Denomination == denom && ae.Burn.Owner.Equal(address) {
+				return true
+			}
+		case ae.Mint != nil:
+			if ae.Mint.Amount.Denomination == denom && ae.Mint.Owner.Equal(address) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Implements V1.
+func (a *v1) DecodeEvent(event *client.Event) ([]client.DecodedEvent, error) {
+	if event.Module != ModuleName {
+		return nil, nil
+	}
+
+	var ev Event
+	switch event.Code {
+	case TransferEventCode:
+		ev.Transfer = &TransferEvent{}
+		if err := types.UnmarshalEvent(event.Value, ev.Transfer, a.strictEvents); err != nil {
+			return nil, fmt.Errorf("decode accounts transfer event value: %w", err)
+		}
+	case BurnEventCode:
+		ev.Burn = &BurnEvent{}
+		if err := types.UnmarshalEvent(event.Value, ev.Burn, a.strictEvents); err != nil {
+			return nil, fmt.Errorf("decode accounts burn event value: %w", err)
+		}
+	case MintEventCode:
+		ev.Mint = &MintEvent{}
+		if err := types.UnmarshalEvent(event.Value, ev.Mint, a.strictEvents); err != nil {
+			return nil, fmt.Errorf("decode accounts mint event value: %w", err)
+		}
+	default:
+		if !a.strictEvents {
+			// Ignore events unknown to this version of the client.
+			return nil, nil
+		}
+		return nil, fmt.Errorf("invalid accounts event code: %v", event.Code)
+	}
+	return []client.DecodedEvent{&ev}, nil
+}
+
 // NewV1 generates a V1 client helper for the accounts module.
+//
+// Events are decoded leniently, ignoring unknown event fields and codes so that decoding keeps
+// working after runtime upgrades that extend events.
 func NewV1(rc client.RuntimeClient) V1 {
 	return &v1{rc: rc}
 }
 
+// NewStrictV1 generates a V1 client helper for the accounts module which decodes events strictly,
+// rejecting unknown event fields and codes. This is intended for tests.
+func NewStrictV1(rc client.RuntimeClient) V1 {
+	return &v1{rc: rc, strictEvents: true}
+}
+
 // NewTransferTx generates a new accounts.Transfer transaction.
 func NewTransferTx(fee *types.Fee, body *Transfer) *types.Transaction {
 	return types.NewTransaction(fee, methodTransfer, body)
@@ -0,0 +1,195 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// supplyTestClient is a fake runtime client serving accounts module queries.
+type supplyTestClient struct {
+	client.RuntimeClient
+
+	latestRound   uint64
+	balances      map[types.Address]map[types.Denomination]uint64
+	totalSupplies map[types.Denomination]uint64
+
+	queriedRounds map[uint64]bool
+	addressPages  int
+}
+
+func (tc *supplyTestClient) GetBlock(ctx context.Context, round uint64) (*block.Block, error) {
+	if round == client.RoundLatest {
+		round = tc.latestRound
+	}
+	var blk block.Block
+	blk.Header.Round = round
+	return &blk, nil
+}
+
+func (tc *supplyTestClient) Query(ctx context.Context, round uint64, method string, args, rsp interface{}) error {
+	tc.queriedRounds[round] = true
+
+	switch method {
+	case methodTotalSupplies:
+		ts := make(map[types.Denomination]types.Quantity)
+		for denom, amount := range tc.totalSupplies {
+			ts[denom] = *quantity.NewFromUint64(amount)
+		}
+		*rsp.(*map[types.Denomination]types.Quantity) = ts
+	case methodAddresses:
+		query := args.(*AddressesQuery)
+		var addrs []types.Address
+		for addr, bals := range tc.balances {
+			if _, ok := bals[query.Denomination]; ok {
+				addrs = append(addrs, addr)
+			}
+		}
+		types.SortAddresses(addrs)
+		if query.After != nil {
+			start := sort.Search(len(addrs), func(i int) bool {
+				return bytes.Compare(addrs[i][:], query.After[:]) > 0
+			})
+			addrs = addrs[start:]
+		}
+		if query.Limit > 0 && query.Limit < uint64(len(addrs)) {
+			addrs = addrs[:query.Limit]
+		}
+		tc.addressPages++
+		*rsp.(*[]types.Address) = addrs
+	case methodBalances:
+		query := args.(*BalancesQuery)
+		bals := make(map[types.Denomination]types.Quantity)
+		for denom, amount := range tc.balances[query.Address] {
+			bals[denom] = *quantity.NewFromUint64(amount)
+		}
+		rsp.(*AccountBalances).Balances = bals
+	default:
+		return fmt.Errorf("unsupported method: %s", method)
+	}
+	return nil
+}
+
+func TestVerifySupplyInvariant(t *testing.T) {
+	require := require.New(t)
+
+	other := types.Denomination("OTHER")
+	tc := &supplyTestClient{
+		latestRound:   7,
+		balances:      make(map[types.Address]map[types.Denomination]uint64),
+		totalSupplies: make(map[types.Denomination]uint64),
+		queriedRounds: make(map[uint64]bool),
+	}
+	// Use enough holders to require multiple pages.
+	const holders = 2*AddressesPageSize + 100
+	for i := 0; i < holders; i++ {
+		var addr types.Address
+		binary.BigEndian.PutUint64(addr[1:], uint64(i))
+		tc.balances[addr] = map[types.Denomination]uint64{types.NativeDenomination: 10}
+		tc.totalSupplies[types.NativeDenomination] += 10
+	}
+	var otherHolder types.Address
+	otherHolder[0] = 0xff
+	tc.balances[otherHolder] = map[types.Denomination]uint64{other: 5}
+	tc.totalSupplies[other] = 6
+
+	ac := NewV1(tc)
+	ctx := context.Background()
+
+	addrs, err := ac.Addresses(ctx, client.RoundLatest, types.NativeDenomination)
+	require.NoError(err)
+	require.Len(addrs, holders, "all holders should be returned across pages")
+	require.Equal(3, tc.addressPages)
+
+	check, err := ac.VerifySupplyInvariant(ctx, client.RoundLatest, types.NativeDenomination)
+	require.NoError(err)
+	require.True(check.IsValid(), "native supply should be consistent")
+	require.EqualValues(7, check.Round)
+	require.Equal(map[uint64]bool{7: true}, tc.queriedRounds, "all queries should be made at the same round")
+
+	check, err = ac.VerifySupplyInvariant(ctx, client.RoundLatest, other)
+	require.NoError(err)
+	require.False(check.IsValid(), "inconsistent supply should be detected")
+	require.EqualValues(6, check.TotalSupply.ToBigInt().Uint64())
+	require.EqualValues(5, check.ComputedSupply.ToBigInt().Uint64())
+}
+
+func TestVestingSchedule(t *testing.T) {
+	require := require.New(t)
+
+	tc := &supplyTestClient{queriedRounds: make(map[uint64]bool)}
+	ac := NewV1(tc)
+
+	schedule, err := ac.VestingSchedule(context.Background(), client.RoundLatest, CommonPoolAddress)
+	require.Nil(schedule)
+	require.True(errors.Is(err, ErrUnsupported), "vesting should be reported as unsupported")
+	var uerr *UnsupportedError
+	require.True(errors.As(err, &uerr))
+	require.Empty(tc.queriedRounds, "no query should be made")
+}
+
+// noopSubscription is a subscription used in tests.
+type noopSubscription struct{}
+
+func (noopSubscription) Close() {}
+
+// watchTestClient is a fake runtime client serving blocks from a channel, which fails to fetch
+// events.
+type watchTestClient struct {
+	*supplyTestClient
+
+	blocks chan *roothash.AnnotatedBlock
+}
+
+func (tc *watchTestClient) WatchBlocks(ctx context.Context) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	return tc.blocks, noopSubscription{}, nil
+}
+
+func (tc *watchTestClient) GetEvents(ctx context.Context, round uint64) ([]*coreClient.Event, error) {
+	return nil, fmt.Errorf("get events failed")
+}
+
+func TestWatchBalanceError(t *testing.T) {
+	require := require.New(t)
+
+	var addr types.Address
+	tc := &watchTestClient{
+		supplyTestClient: &supplyTestClient{
+			latestRound:   3,
+			balances:      map[types.Address]map[types.Denomination]uint64{addr: {types.NativeDenomination: 10}},
+			queriedRounds: make(map[uint64]bool),
+		},
+		blocks: make(chan *roothash.AnnotatedBlock, 1),
+	}
+	ac := NewV1(tc)
+
+	ch, errCh, err := ac.WatchBalance(context.Background(), addr, types.NativeDenomination)
+	require.NoError(err)
+
+	update := <-ch
+	require.EqualValues(3, update.Round)
+	require.EqualValues(10, update.Balance.ToBigInt().Uint64())
+
+	var blk block.Block
+	blk.Header.Round = 4
+	tc.blocks <- &roothash.AnnotatedBlock{Block: &blk}
+	err = <-errCh
+	require.Error(err, "failure to fetch events should be reported")
+	_, ok := <-ch
+	require.False(ok, "update channel should be closed after an error")
+}
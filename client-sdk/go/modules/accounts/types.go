@@ -1,6 +1,8 @@
 package accounts
 
 import (
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 )
 
@@ -20,7 +22,97 @@ type BalancesQuery struct {
 	Address types.Address `json:"address"`
 }
 
+// AddressesQuery are the arguments for the accounts.Addresses query.
+type AddressesQuery struct {
+	Denomination types.Denomination `json:"denomination"`
+	// After is the address after which (in address order) addresses are returned. It should be
+	// set to the last address of the previous page when paginating.
+	After *types.Address `json:"after,omitempty"`
+	// Limit is the maximum number of addresses to return. Zero means no limit.
+	Limit uint64 `json:"limit,omitempty"`
+}
+
 // AccountBalances are the balances in an account.
 type AccountBalances struct {
 	Balances map[types.Denomination]types.Quantity `json:"balances"`
 }
+
+// VestingScheduleEntry describes the balances of an account starting at the given epoch.
+type VestingScheduleEntry struct {
+	// Epoch is the epoch at which the entry takes effect.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// Locked are the balances that can not yet be transferred.
+	Locked map[types.Denomination]types.Quantity `json:"locked"`
+	// Unlocked are the balances that are available for transfer.
+	Unlocked map[types.Denomination]types.Quantity `json:"unlocked"`
+}
+
+// VestingSchedule is the vesting schedule of an account.
+//
+// Note that the accounts module does not currently support vesting so no runtime query returns
+// this type yet.
+type VestingSchedule struct {
+	// Entries are the schedule entries ordered by epoch.
+	Entries []VestingScheduleEntry `json:"entries"`
+}
+
+// TransferEventCode is the event code for the transfer event.
+const TransferEventCode = 1
+
+// TransferEvent is the transfer event.
+type TransferEvent struct {
+	From   types.Address   `json:"from"`
+	To     types.Address   `json:"to"`
+	Amount types.BaseUnits `json:"amount"`
+}
+
+// BurnEventCode is the event code for the burn event.
+const BurnEventCode = 2
+
+// BurnEvent is the burn event.
+type BurnEvent struct {
+	Owner  types.Address   `json:"owner"`
+	Amount types.BaseUnits `json:"amount"`
+}
+
+// MintEventCode is the event code for the mint event.
+const MintEventCode = 3
+
+// MintEvent is the mint event.
+type MintEvent struct {
+	Owner  types.Address   `json:"owner"`
+	Amount types.BaseUnits `json:"amount"`
+}
+
+// Event is an account event.
+type Event struct {
+	Transfer *TransferEvent
+	Burn     *BurnEvent
+	Mint     *MintEvent
+}
+
+// BalanceUpdate is an update of an account's balance.
+type BalanceUpdate struct {
+	// Round is the round at which the balance was queried.
+	Round uint64
+	// Balance is the account's balance.
+	Balance types.Quantity
+}
+
+// SupplyCheck is the result of verifying that the sum of all account balances of a given
+// denomination matches its total supply.
+type SupplyCheck struct {
+	// Round is the round at which the check was performed.
+	Round uint64
+	// Denomination is the checked denomination.
+	Denomination types.Denomination
+	// TotalSupply is the total supply as tracked by the accounts module.
+	TotalSupply types.Quantity
+	// ComputedSupply is the sum of all account balances.
+	ComputedSupply types.Quantity
+}
+
+// IsValid returns true iff the computed supply matches the total supply.
+func (sc *SupplyCheck) IsValid() bool {
+	return sc.TotalSupply.Cmp(&sc.ComputedSupply) == 0
+}
@@ -2,13 +2,19 @@ package consensusaccounts
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 )
 
+// ModuleName is the consensus accounts module name.
+const ModuleName = "consensus_accounts"
+
 const (
 	// Callable methods.
 	methodDeposit  = "consensus.Deposit"
@@ -19,6 +25,10 @@ const (
 	methodAccount = "consensus.Account"
 )
 
+// ErrUnreachableWithdrawal is the error returned when withdrawn tokens would be unreachable in
+// the consensus layer.
+var ErrUnreachableWithdrawal = errors.New("consensusaccounts: withdrawn tokens would be unreachable")
+
 // V1 is the v1 consensus accounts module interface.
 type V1 interface {
 	// Deposit generates a consensus.Deposit transaction.
@@ -27,15 +37,37 @@ type V1 interface {
 	// Withdraw generates a consensus.Withdraw transaction.
 	Withdraw(amount types.BaseUnits) *client.TransactionBuilder
 
+	// WithdrawChecked generates a consensus.Withdraw transaction after checking that the
+	// withdrawn tokens will be spendable in the consensus layer by the signer with the given
+	// address specification.
+	//
+	// Withdrawals are credited to the consensus account with the same address as the signer.
+	// As the consensus layer only supports Ed25519 signers, withdrawals by any other kind of
+	// signer (e.g., secp256k1 or multisig) would make the tokens unreachable in which case
+	// ErrUnreachableWithdrawal is returned.
+	WithdrawChecked(amount types.BaseUnits, signer types.AddressSpec) (*client.TransactionBuilder, error)
+
 	// Balance queries the given account's balance of consensus denomination tokens.
 	Balance(ctx context.Context, round uint64, query *BalanceQuery) (*AccountBalance, error)
 
 	// ConsensusAccount queries the given consensus layer account.
 	ConsensusAccount(ctx context.Context, round uint64, query *AccountQuery) (*staking.Account, error)
+
+	// RuntimeConsensusBalance queries the general balance of the runtime's own account in the
+	// consensus layer (e.g., holding the tokens deposited into the runtime).
+	RuntimeConsensusBalance(ctx context.Context, round uint64) (*types.Quantity, error)
+
+	// DecodeEvent decodes a consensus accounts event.
+	//
+	// The consensus accounts module does not currently define any events, so in strict mode any
+	// event emitted by the module is rejected while in lenient mode such events are ignored.
+	DecodeEvent(event *client.Event) ([]client.DecodedEvent, error)
 }
 
 type v1 struct {
 	rc client.RuntimeClient
+
+	strictEvents bool
 }
 
 // Implements V1.
@@ -52,6 +84,19 @@ func (a *v1) Withdraw(amount types.BaseUnits) *client.TransactionBuilder {
 	})
 }
 
+// Implements V1.
+func (a *v1) WithdrawChecked(amount types.BaseUnits, signer types.AddressSpec) (*client.TransactionBuilder, error) {
+	if signer.Signature == nil {
+		return nil, fmt.Errorf("%w: multisig signers cannot spend from consensus accounts", ErrUnreachableWithdrawal)
+	}
+	switch signer.Signature.PublicKey.(type) {
+	case ed25519.PublicKey, *ed25519.PublicKey:
+	default:
+		return nil, fmt.Errorf("%w: only Ed25519 signers can spend from consensus accounts", ErrUnreachableWithdrawal)
+	}
+	return a.Withdraw(amount), nil
+}
+
 // Implements V1.
 func (a *v1) Balance(ctx context.Context, round uint64, query *BalanceQuery) (*AccountBalance, error) {
 	var balance AccountBalance
@@ -72,11 +117,48 @@ func (a *v1) ConsensusAccount(ctx context.Context, round uint64, query *AccountQ
 	return &account, nil
 }
 
+// Implements V1.
+func (a *v1) RuntimeConsensusBalance(ctx context.Context, round uint64) (*types.Quantity, error) {
+	info, err := a.rc.GetInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve runtime info: %w", err)
+	}
+
+	account, err := a.ConsensusAccount(ctx, round, &AccountQuery{
+		Address: types.Address(staking.NewRuntimeAddress(info.ID)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &account.General.Balance, nil
+}
+
+// Implements V1.
+func (a *v1) DecodeEvent(event *client.Event) ([]client.DecodedEvent, error) {
+	if event.Module != ModuleName {
+		return nil, nil
+	}
+	if !a.strictEvents {
+		// Ignore events unknown to this version of the client.
+		return nil, nil
+	}
+	return nil, fmt.Errorf("invalid consensus accounts event code: %v", event.Code)
+}
+
 // NewV1 generates a V1 client helper for the consensus accounts module.
+//
+// Events are decoded leniently, ignoring unknown event fields and codes so that decoding keeps
+// working after runtime upgrades that extend events.
 func NewV1(rc client.RuntimeClient) V1 {
 	return &v1{rc: rc}
 }
 
+// NewStrictV1 generates a V1 client helper for the consensus accounts module which decodes
+// events strictly, rejecting unknown event fields and codes. This is intended for tests.
+func NewStrictV1(rc client.RuntimeClient) V1 {
+	return &v1{rc: rc, strictEvents: true}
+}
+
 // NewDepositTx generates a new consensus.Deposit transaction.
 func NewDepositTx(fee *types.Fee, body *Deposit) *types.Transaction {
 	return types.NewTransaction(fee, methodDeposit, body)
@@ -0,0 +1,27 @@
+package consensusaccounts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	require := require.New(t)
+
+	ev := &client.Event{Module: ModuleName, Code: 1, Value: []byte{0xa0}}
+	other := &client.Event{Module: "accounts", Code: 1, Value: []byte{0xa0}}
+
+	evs, err := NewV1(nil).DecodeEvent(ev)
+	require.NoError(err, "lenient decoding should ignore unknown events")
+	require.Empty(evs)
+
+	_, err = NewStrictV1(nil).DecodeEvent(ev)
+	require.Error(err, "strict decoding should reject unknown events")
+
+	evs, err = NewStrictV1(nil).DecodeEvent(other)
+	require.NoError(err, "events of other modules should be ignored")
+	require.Empty(evs)
+}
@@ -2,21 +2,81 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 )
 
 const (
-	methodEstimateGas = "core.EstimateGas"
+	methodEstimateGas     = "core.EstimateGas"
+	methodParameters      = "core.Parameters"
+	methodEpoch           = "core.Epoch"
+	methodEmittedMessages = "core.EmittedMessages"
+
+	// ParametersRefreshInterval is the interval after which the parameters cached by
+	// LocalGasEstimate are re-fetched.
+	ParametersRefreshInterval = 10 * time.Minute
 )
 
 type V1 interface {
 	EstimateGas(ctx context.Context, round uint64, tx *types.Transaction) (uint64, error)
+
+	// EstimateGasRange estimates the amount of gas used by the given transaction together with a
+	// low/high range of the estimates for similar transactions (calling the same method) that
+	// were included in the given number of rounds up to and including the given round.
+	//
+	// The runtime does not report the gas actually used by transactions. Each similar transaction
+	// is instead re-estimated against the state preceding its round, so the range shows how
+	// estimates for the method vary with recent arguments and state. It does not bound the gas
+	// used by the given transaction. Samples that can no longer be estimated (e.g., because
+	// another transaction of the same signer preceded them in the same round) are skipped.
+	//
+	// Sampling costs one GetTransactions request per sampled round plus one EstimateGas query per
+	// similar transaction. In case sampleRounds is zero, only the point estimate is returned.
+	EstimateGasRange(ctx context.Context, round uint64, tx *types.Transaction, sampleRounds uint64) (*GasEstimateRange, error)
+
+	// Epoch queries the consensus layer epoch as observed by the runtime at the given round.
+	//
+	// The runtime does not expose the consensus layer epoch transition schedule, so use the
+	// consensus layer beacon backend to determine when the next epoch transition will occur.
+	Epoch(ctx context.Context, round uint64) (beacon.EpochTime, error)
+
+	// EmittedMessages queries the number of consensus messages emitted by the runtime in the
+	// given round.
+	EmittedMessages(ctx context.Context, round uint64) (uint32, error)
+
+	// Parameters queries the core module parameters.
+	Parameters(ctx context.Context, round uint64) (*Parameters, error)
+
+	// Limits queries the runtime limits enforced by the core module.
+	Limits(ctx context.Context, round uint64) (*Limits, error)
+
+	// ParametersChanged compares the core module parameters between the two given rounds and
+	// returns the list of changed parameters.
+	ParametersChanged(ctx context.Context, roundA, roundB uint64) (bool, []ParameterChange, error)
+
+	// LocalGasEstimate computes an approximate amount of gas used by the given transaction
+	// without executing it.
+	//
+	// The estimate only includes the gas charged by the core module for transaction
+	// authentication, based on core module parameters which are cached and only re-fetched after
+	// ParametersRefreshInterval has elapsed. It does not include any gas used by the called
+	// method so EstimateGas remains authoritative.
+	LocalGasEstimate(ctx context.Context, tx *types.Transaction) (uint64, error)
 }
 
 type v1 struct {
 	rc client.RuntimeClient
+
+	paramsLock    sync.Mutex
+	cachedParams  *Parameters
+	paramsFetched time.Time
 }
 
 // Implements V1.
@@ -29,6 +89,133 @@ func (a *v1) EstimateGas(ctx context.Context, round uint64, tx *types.Transactio
 	return gas, nil
 }
 
+// Implements V1.
+func (a *v1) EstimateGasRange(ctx context.Context, round uint64, tx *types.Transaction, sampleRounds uint64) (*GasEstimateRange, error) {
+	blk, err := a.rc.GetBlock(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block for round %d: %w", round, err)
+	}
+	round = blk.Header.Round
+
+	expected, err := a.EstimateGas(ctx, round, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	rng := &GasEstimateRange{
+		Low:      expected,
+		Expected: expected,
+		High:     expected,
+	}
+	if sampleRounds == 0 {
+		return rng, nil
+	}
+	fromRound := uint64(0)
+	if round >= sampleRounds {
+		fromRound = round - sampleRounds + 1
+	}
+	for r := fromRound; r <= round; r++ {
+		txs, err := a.rc.GetTransactions(ctx, r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch transactions for round %d: %w", r, err)
+		}
+		for _, utx := range txs {
+			var sample types.Transaction
+			if err = cbor.Unmarshal(utx.Body, &sample); err != nil {
+				// Skip malformed transactions.
+				continue
+			}
+			if sample.Call.Method != tx.Call.Method || r == 0 {
+				continue
+			}
+
+			gas, err := a.EstimateGas(ctx, r-1, &sample)
+			if err != nil {
+				// Skip transactions that cannot be estimated.
+				continue
+			}
+			if gas < rng.Low {
+				rng.Low = gas
+			}
+			if gas > rng.High {
+				rng.High = gas
+			}
+			rng.Samples++
+		}
+	}
+	return rng, nil
+}
+
+// Implements V1.
+func (a *v1) Epoch(ctx context.Context, round uint64) (beacon.EpochTime, error) {
+	var epoch beacon.EpochTime
+	err := a.rc.Query(ctx, round, methodEpoch, nil, &epoch)
+	if err != nil {
+		return 0, err
+	}
+	return epoch, nil
+}
+
+// Implements V1.
+func (a *v1) EmittedMessages(ctx context.Context, round uint64) (uint32, error) {
+	var count uint32
+	err := a.rc.Query(ctx, round, methodEmittedMessages, nil, &count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Implements V1.
+func (a *v1) Parameters(ctx context.Context, round uint64) (*Parameters, error) {
+	var params Parameters
+	err := a.rc.Query(ctx, round, methodParameters, nil, &params)
+	if err != nil {
+		return nil, err
+	}
+	return &params, nil
+}
+
+// Implements V1.
+func (a *v1) Limits(ctx context.Context, round uint64) (*Limits, error) {
+	params, err := a.Parameters(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	return params.Limits(), nil
+}
+
+// Implements V1.
+func (a *v1) ParametersChanged(ctx context.Context, roundA, roundB uint64) (bool, []ParameterChange, error) {
+	paramsA, err := a.Parameters(ctx, roundA)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to query parameters for round %d: %w", roundA, err)
+	}
+	paramsB, err := a.Parameters(ctx, roundB)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to query parameters for round %d: %w", roundB, err)
+	}
+
+	changes := paramsA.Diff(paramsB)
+	return len(changes) > 0, changes, nil
+}
+
+// Implements V1.
+func (a *v1) LocalGasEstimate(ctx context.Context, tx *types.Transaction) (uint64, error) {
+	a.paramsLock.Lock()
+	defer a.paramsLock.Unlock()
+
+	if a.cachedParams == nil || time.Since(a.paramsFetched) >= ParametersRefreshInterval {
+		params, err := a.Parameters(ctx, client.RoundLatest)
+		if err != nil {
+			return 0, fmt.Errorf("failed to query parameters: %w", err)
+		}
+		a.cachedParams = params
+		a.paramsFetched = time.Now()
+	}
+	return a.cachedParams.AuthGas(&tx.AuthInfo)
+}
+
 func NewV1(rc client.RuntimeClient) V1 {
 	return &v1{rc: rc}
 }
@@ -0,0 +1,147 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// gasTestClient is a fake runtime client serving blocks, transactions and gas estimates.
+type gasTestClient struct {
+	client.RuntimeClient
+
+	latestRound uint64
+	txs         map[uint64][]*types.Transaction
+	// gasUsed is the gas used by each transaction (keyed by nonce) when estimated at the given
+	// round.
+	gasUsed map[uint64]map[uint64]uint64
+
+	params      *Parameters
+	paramsCalls int
+}
+
+func (tc *gasTestClient) GetBlock(ctx context.Context, round uint64) (*block.Block, error) {
+	if round == client.RoundLatest {
+		round = tc.latestRound
+	}
+	var blk block.Block
+	blk.Header.Round = round
+	return &blk, nil
+}
+
+func (tc *gasTestClient) GetTransactions(ctx context.Context, round uint64) ([]*types.UnverifiedTransaction, error) {
+	var utxs []*types.UnverifiedTransaction
+	for _, tx := range tc.txs[round] {
+		utxs = append(utxs, &types.UnverifiedTransaction{Body: cbor.Marshal(tx)})
+	}
+	return utxs, nil
+}
+
+func (tc *gasTestClient) Query(ctx context.Context, round uint64, method string, args, rsp interface{}) error {
+	switch method {
+	case methodEstimateGas:
+	case methodParameters:
+		tc.paramsCalls++
+		*rsp.(*Parameters) = *tc.params
+		return nil
+	default:
+		return fmt.Errorf("unsupported method: %s", method)
+	}
+	tx := args.(*types.Transaction)
+	gas, ok := tc.gasUsed[round][tx.AuthInfo.SignerInfo[0].Nonce]
+	if !ok {
+		return fmt.Errorf("estimation failed")
+	}
+	*rsp.(*uint64) = gas
+	return nil
+}
+
+func TestEstimateGasRange(t *testing.T) {
+	require := require.New(t)
+
+	pk := ed25519.NewPublicKey("NcPzNW3YU2T+ugNUtUWtoQnRvbOL9dYSaBfbjHLP1pE=")
+	newTx := func(method string, gasLimit, nonce uint64) *types.Transaction {
+		tx := types.NewTransaction(&types.Fee{Gas: gasLimit}, method, nil)
+		tx.AppendAuthSignature(pk, nonce)
+		return tx
+	}
+
+	tc := &gasTestClient{
+		latestRound: 3,
+		txs: map[uint64][]*types.Transaction{
+			// Declared gas limits are far above the gas used and must not affect the range.
+			2: {newTx("test.Foo", 1_000_000, 1)},
+			3: {
+				newTx("test.Foo", 999_999, 2),
+				newTx("test.Bar", 10, 3),
+				newTx("test.Foo", 1_000_000, 4),
+			},
+		},
+		gasUsed: map[uint64]map[uint64]uint64{
+			1: {1: 300},
+			2: {2: 700, 3: 10},
+			3: {0: 500},
+		},
+	}
+	cc := NewV1(tc)
+
+	ctx := context.Background()
+	rng, err := cc.EstimateGasRange(ctx, client.RoundLatest, newTx("test.Foo", 0, 0), 10)
+	require.NoError(err)
+	require.EqualValues(500, rng.Expected)
+	require.EqualValues(300, rng.Low, "low bound should be the lowest estimate")
+	require.EqualValues(700, rng.High, "high bound should be the highest estimate")
+	require.EqualValues(2, rng.Samples, "only similar transactions that can be estimated should be sampled")
+
+	rng, err = cc.EstimateGasRange(ctx, client.RoundLatest, newTx("test.Foo", 0, 0), 1)
+	require.NoError(err)
+	require.EqualValues(500, rng.Low, "only the given number of rounds should be sampled")
+	require.EqualValues(700, rng.High)
+	require.EqualValues(1, rng.Samples)
+
+	rng, err = cc.EstimateGasRange(ctx, client.RoundLatest, newTx("test.Foo", 0, 0), 0)
+	require.NoError(err)
+	require.Equal(&GasEstimateRange{Low: 500, Expected: 500, High: 500}, rng, "no rounds should be sampled")
+}
+
+func TestLocalGasEstimate(t *testing.T) {
+	require := require.New(t)
+
+	pk := ed25519.NewPublicKey("NcPzNW3YU2T+ugNUtUWtoQnRvbOL9dYSaBfbjHLP1pE=")
+	tx := types.NewTransaction(nil, "test.Foo", nil)
+	tx.AppendAuthSignature(pk, 0)
+
+	tc := &gasTestClient{
+		params: &Parameters{GasCosts: GasCosts{AuthSignature: 10}},
+	}
+	cc := NewV1(tc).(*v1)
+	ctx := context.Background()
+
+	gas, err := cc.LocalGasEstimate(ctx, tx)
+	require.NoError(err)
+	require.EqualValues(10, gas)
+	require.Equal(1, tc.paramsCalls)
+
+	// Parameters should be served from cache.
+	tc.params = &Parameters{GasCosts: GasCosts{AuthSignature: 20}}
+	gas, err = cc.LocalGasEstimate(ctx, tx)
+	require.NoError(err)
+	require.EqualValues(10, gas)
+	require.Equal(1, tc.paramsCalls)
+
+	// Parameters should be re-fetched after the refresh interval.
+	cc.paramsFetched = cc.paramsFetched.Add(-ParametersRefreshInterval)
+	gas, err = cc.LocalGasEstimate(ctx, tx)
+	require.NoError(err)
+	require.EqualValues(20, gas)
+	require.Equal(2, tc.paramsCalls)
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// GasCosts are the core module gas costs.
+type GasCosts struct {
+	AuthSignature      uint64 `json:"auth_signature"`
+	AuthMultisigSigner uint64 `json:"auth_multisig_signer"`
+}
+
+// Parameters are the parameters for the core module.
+type Parameters struct {
+	MaxBatchGas        uint64   `json:"max_batch_gas"`
+	MaxTxSigners       uint32   `json:"max_tx_signers"`
+	MaxMultisigSigners uint32   `json:"max_multisig_signers"`
+	GasCosts           GasCosts `json:"gas_costs"`
+}
+
+// Limits are the runtime limits enforced by the core module.
+//
+// Note that this runtime version does not limit the transaction size or the number of emitted
+// consensus messages via core module parameters, nor does it charge gas per transaction byte.
+type Limits struct {
+	// MaxBatchGas is the maximum amount of gas that can be used by all transactions in a batch.
+	MaxBatchGas uint64
+	// MaxTxSigners is the maximum number of signers of a single transaction.
+	MaxTxSigners uint32
+	// MaxMultisigSigners is the maximum number of signers in a multisig configuration.
+	MaxMultisigSigners uint32
+}
+
+// Limits returns the runtime limits defined by the parameters.
+func (p *Parameters) Limits() *Limits {
+	return &Limits{
+		MaxBatchGas:        p.MaxBatchGas,
+		MaxTxSigners:       p.MaxTxSigners,
+		MaxMultisigSigners: p.MaxMultisigSigners,
+	}
+}
+
+// ParameterChange is a change of a single module parameter.
+type ParameterChange struct {
+	// Field is the name of the changed parameter.
+	Field string
+	// Old is the old value of the parameter.
+	Old interface{}
+	// New is the new value of the parameter.
+	New interface{}
+}
+
+// String returns a string representation of the parameter change.
+func (pc ParameterChange) String() string {
+	return fmt.Sprintf("%s: %v -> %v", pc.Field, pc.Old, pc.New)
+}
+
+// Diff returns the list of parameters that differ between these and the other parameters.
+func (p *Parameters) Diff(other *Parameters) []ParameterChange {
+	var changes []ParameterChange
+	appendIfChanged := func(field string, oldValue, newValue interface{}) {
+		if oldValue != newValue {
+			changes = append(changes, ParameterChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+
+	appendIfChanged("max_batch_gas", p.MaxBatchGas, other.MaxBatchGas)
+	appendIfChanged("max_tx_signers", p.MaxTxSigners, other.MaxTxSigners)
+	appendIfChanged("max_multisig_signers", p.MaxMultisigSigners, other.MaxMultisigSigners)
+	appendIfChanged("gas_costs.auth_signature", p.GasCosts.AuthSignature, other.GasCosts.AuthSignature)
+	appendIfChanged("gas_costs.auth_multisig_signer", p.GasCosts.AuthMultisigSigner, other.GasCosts.AuthMultisigSigner)
+
+	return changes
+}
+
+// AuthGas computes the amount of gas charged by the core module for authenticating a transaction
+// with the given authentication information.
+func (p *Parameters) AuthGas(ai *types.AuthInfo) (uint64, error) {
+	var numSignature, numMultisigSigner uint64
+	for _, si := range ai.SignerInfo {
+		switch {
+		case si.AddressSpec.Signature != nil:
+			numSignature++
+		case si.AddressSpec.Multisig != nil:
+			numMultisigSigner += uint64(len(si.AddressSpec.Multisig.Signers))
+		}
+	}
+
+	hi, signatureCost := bits.Mul64(numSignature, p.GasCosts.AuthSignature)
+	if hi != 0 {
+		return 0, fmt.Errorf("gas overflow")
+	}
+	hi, multisigSignerCost := bits.Mul64(numMultisigSigner, p.GasCosts.AuthMultisigSigner)
+	if hi != 0 {
+		return 0, fmt.Errorf("gas overflow")
+	}
+	total, carry := bits.Add64(signatureCost, multisigSignerCost, 0)
+	if carry != 0 {
+		return 0, fmt.Errorf("gas overflow")
+	}
+	return total, nil
+}
+
+// GasEstimateRange is a gas estimate together with the range of estimates for similar
+// transactions in recent rounds.
+type GasEstimateRange struct {
+	// Low is the lowest of the point estimate and the estimates for the sampled transactions.
+	Low uint64
+	// Expected is the point estimate as returned by EstimateGas.
+	Expected uint64
+	// High is the highest of the point estimate and the estimates for the sampled transactions.
+	High uint64
+	// Samples is the number of similar transactions that were estimated.
+	Samples int
+}
@@ -0,0 +1,155 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestParametersAuthGas(t *testing.T) {
+	require := require.New(t)
+
+	pk1 := ed25519.NewPublicKey("NcPzNW3YU2T+ugNUtUWtoQnRvbOL9dYSaBfbjHLP1pE=")
+	pk2 := ed25519.NewPublicKey("YgkEiVSR4SMQdfXw+ppuFYlqH0seutnCKk8KG8PyAx0=")
+	multisig := &types.MultisigConfig{
+		Signers: []types.MultisigSigner{
+			{PublicKey: types.PublicKey{PublicKey: pk1}, Weight: 1},
+			{PublicKey: types.PublicKey{PublicKey: pk2}, Weight: 1},
+		},
+		Threshold: 2,
+	}
+	params := &Parameters{
+		GasCosts: GasCosts{
+			AuthSignature:      1000,
+			AuthMultisigSigner: 100,
+		},
+	}
+
+	for _, tc := range []struct {
+		name        string
+		params      *Parameters
+		signerInfo  []types.SignerInfo
+		expectedGas uint64
+		expectedErr bool
+	}{
+		{"NoSigners", params, nil, 0, false},
+		{
+			"SingleSignature",
+			params,
+			[]types.SignerInfo{{AddressSpec: types.NewAddressSpecSignature(pk1)}},
+			1000,
+			false,
+		},
+		{
+			"Multisig",
+			params,
+			[]types.SignerInfo{{AddressSpec: types.NewAddressSpecMultisig(multisig)}},
+			200,
+			false,
+		},
+		{
+			"Mixed",
+			params,
+			[]types.SignerInfo{
+				{AddressSpec: types.NewAddressSpecSignature(pk1)},
+				{AddressSpec: types.NewAddressSpecSignature(pk2)},
+				{AddressSpec: types.NewAddressSpecMultisig(multisig)},
+			},
+			2200,
+			false,
+		},
+		{
+			"Overflow",
+			&Parameters{GasCosts: GasCosts{AuthSignature: math.MaxUint64}},
+			[]types.SignerInfo{
+				{AddressSpec: types.NewAddressSpecSignature(pk1)},
+				{AddressSpec: types.NewAddressSpecSignature(pk2)},
+			},
+			0,
+			true,
+		},
+	} {
+		gas, err := tc.params.AuthGas(&types.AuthInfo{SignerInfo: tc.signerInfo})
+		if tc.expectedErr {
+			require.Error(err, tc.name)
+			continue
+		}
+		require.NoError(err, tc.name)
+		require.EqualValues(tc.expectedGas, gas, tc.name)
+	}
+}
+
+func TestParametersDiff(t *testing.T) {
+	require := require.New(t)
+
+	params := Parameters{
+		MaxBatchGas:        10_000,
+		MaxTxSigners:       8,
+		MaxMultisigSigners: 8,
+		GasCosts: GasCosts{
+			AuthSignature:      1000,
+			AuthMultisigSigner: 100,
+		},
+	}
+
+	require.Empty(params.Diff(&params), "identical parameters should have no changes")
+
+	other := params
+	other.MaxBatchGas = 20_000
+	other.GasCosts.AuthMultisigSigner = 200
+	changes := params.Diff(&other)
+	require.Equal([]ParameterChange{
+		{Field: "max_batch_gas", Old: uint64(10_000), New: uint64(20_000)},
+		{Field: "gas_costs.auth_multisig_signer", Old: uint64(100), New: uint64(200)},
+	}, changes)
+	require.Equal("max_batch_gas: 10000 -> 20000", changes[0].String())
+
+	other = params
+	other.MaxTxSigners = 4
+	other.MaxMultisigSigners = 2
+	other.GasCosts.AuthSignature = 500
+	require.Equal([]ParameterChange{
+		{Field: "max_tx_signers", Old: uint32(8), New: uint32(4)},
+		{Field: "max_multisig_signers", Old: uint32(8), New: uint32(2)},
+		{Field: "gas_costs.auth_signature", Old: uint64(1000), New: uint64(500)},
+	}, params.Diff(&other))
+}
+
+func TestParametersLimits(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		name     string
+		params   Parameters
+		expected Limits
+	}{
+		{"Empty", Parameters{}, Limits{}},
+		{
+			"All",
+			Parameters{
+				MaxBatchGas:        10_000,
+				MaxTxSigners:       8,
+				MaxMultisigSigners: 16,
+			},
+			Limits{
+				MaxBatchGas:        10_000,
+				MaxTxSigners:       8,
+				MaxMultisigSigners: 16,
+			},
+		},
+		{
+			"GasCostsIgnored",
+			Parameters{
+				MaxBatchGas: 1,
+				GasCosts:    GasCosts{AuthSignature: 1000, AuthMultisigSigner: 100},
+			},
+			Limits{MaxBatchGas: 1},
+		},
+	} {
+		require.Equal(&tc.expected, tc.params.Limits(), tc.name)
+	}
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+)
+
+// Account bundles a signer together with its signature address specification and the derived
+// account address.
+type Account struct {
+	// Signer is the signer used to authenticate as the account.
+	Signer signature.Signer
+	// AddressSpec is the signature address specification for the signer.
+	AddressSpec AddressSpec
+	// Address is the address derived from the signer's public key.
+	Address Address
+}
+
+// NewAccount creates a new account for the given signer.
+func NewAccount(signer signature.Signer) *Account {
+	pk := signer.Public()
+	return &Account{
+		Signer:      signer,
+		AddressSpec: NewAddressSpecSignature(pk),
+		Address:     NewAddress(pk),
+	}
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+)
+
+func TestAccount(t *testing.T) {
+	require := require.New(t)
+
+	signer := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: account"))
+	acct := NewAccount(signer)
+	require.EqualValues(NewAddress(signer.Public()), acct.Address, "address should be derived from signer")
+	require.NotNil(acct.AddressSpec.Signature, "address spec should be a signature spec")
+
+	addr, err := acct.AddressSpec.Address()
+	require.NoError(err, "AddressSpec.Address")
+	require.EqualValues(acct.Address, addr, "address spec should derive the same address")
+
+	var decoded AddressSpec
+	err = cbor.Unmarshal(cbor.Marshal(acct.AddressSpec), &decoded)
+	require.NoError(err, "cbor.Unmarshal")
+	addr, err = decoded.Address()
+	require.NoError(err, "AddressSpec.Address of decoded spec")
+	require.EqualValues(acct.Address, addr, "decoded address spec should derive the same address")
+
+	tx := NewTransaction(nil, "test.Method", nil)
+	tx.AppendAuthAccount(acct, 42)
+	require.Len(tx.AuthInfo.SignerInfo, 1)
+	require.EqualValues(acct.AddressSpec, tx.AuthInfo.SignerInfo[0].AddressSpec)
+	require.EqualValues(42, tx.AuthInfo.SignerInfo[0].Nonce)
+}
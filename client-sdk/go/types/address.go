@@ -1,10 +1,15 @@
 package types
 
 import (
+	"bytes"
+	"crypto/subtle"
 	"encoding"
+	"fmt"
+	"sort"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/address"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/encoding/bech32"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 
@@ -21,6 +26,8 @@ var (
 	AddressV0Secp256k1Context = address.NewContext("oasis-runtime-sdk/address: secp256k1", 0)
 	// AddressV0MultisigContext is the unique context for v0 multisig addresses.
 	AddressV0MultisigContext = address.NewContext("oasis-runtime-sdk/address: multisig", 0)
+	// AddressV0ModuleContext is the unique context for v0 module addresses.
+	AddressV0ModuleContext = address.NewContext("oasis-runtime-sdk/address: module", 0)
 	// AddressBech32HRP is the unique human readable part of Bech32 encoded
 	// staking account addresses.
 	AddressBech32HRP = staking.AddressBech32HRP
@@ -54,9 +61,22 @@ func (a *Address) UnmarshalText(text []byte) error {
 	return (*address.Address)(a).UnmarshalBech32(AddressBech32HRP, text)
 }
 
-// Equal compares vs another address for equality.
+// Equal compares vs another address for equality in constant time.
 func (a Address) Equal(cmp Address) bool {
-	return (address.Address)(a).Equal((address.Address)(cmp))
+	return subtle.ConstantTimeCompare(a[:], cmp[:]) == 1
+}
+
+// Less returns true iff the address is ordered before the other address. Addresses are ordered
+// by their canonical binary representation.
+func (a Address) Less(other Address) bool {
+	return bytes.Compare(a[:], other[:]) < 0
+}
+
+// SortAddresses sorts the given addresses in place by their canonical binary representation.
+func SortAddresses(addrs []Address) {
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].Less(addrs[j])
+	})
 }
 
 // String returns the string representation of an address.
@@ -68,22 +88,35 @@ func (a Address) String() string {
 	return bech32Addr
 }
 
-// NewAddress creates a new address from the given public key.
-func NewAddress(pk signature.PublicKey) (a Address) {
+// addressContextAndData returns the address derivation context and data for the given public key.
+func addressContextAndData(pk signature.PublicKey) (address.Context, []byte) {
 	var (
 		ctx    address.Context
 		pkData []byte
 	)
+	// Public keys decoded from CBOR or JSON are pointers.
 	switch pk := pk.(type) {
 	case ed25519.PublicKey:
 		ctx = AddressV0Ed25519Context
 		pkData, _ = pk.MarshalBinary()
+	case *ed25519.PublicKey:
+		ctx = AddressV0Ed25519Context
+		pkData, _ = pk.MarshalBinary()
 	case secp256k1.PublicKey:
 		ctx = AddressV0Secp256k1Context
 		pkData, _ = pk.MarshalBinary()
+	case *secp256k1.PublicKey:
+		ctx = AddressV0Secp256k1Context
+		pkData, _ = pk.MarshalBinary()
 	default:
 		panic("address: unsupported public key type")
 	}
+	return ctx, pkData
+}
+
+// NewAddress creates a new address from the given public key.
+func NewAddress(pk signature.PublicKey) (a Address) {
+	ctx, pkData := addressContextAndData(pk)
 	return (Address)(address.NewAddress(ctx, pkData))
 }
 
@@ -102,3 +135,49 @@ func NewAddressFromBech32(data string) (a Address) {
 func NewAddressFromMultisig(config *MultisigConfig) Address {
 	return (Address)(address.NewAddress(AddressV0MultisigContext, cbor.Marshal(config)))
 }
+
+// NewAddressForModule creates a new address for the given module account kind.
+func NewAddressForModule(module, kind string) Address {
+	return (Address)(address.NewAddress(AddressV0ModuleContext, []byte(module+"."+kind)))
+}
+
+// AddressDerivation contains the address together with the intermediate values used during its
+// derivation. It is useful for debugging unexpected addresses.
+type AddressDerivation struct {
+	// Address is the derived address.
+	Address Address
+	// ContextIdentifier is the identifier of the address derivation context.
+	ContextIdentifier string
+	// ContextVersion is the version of the address derivation context.
+	ContextVersion uint8
+	// Data is the address data (e.g., the serialized public key or multisig configuration).
+	Data []byte
+	// Hash is SHA512/256(ContextIdentifier || ContextVersion || Data). The address consists of
+	// the context version followed by the truncated hash.
+	Hash hash.Hash
+}
+
+// DeriveAddressVerbose derives the address for the given address specification and returns it
+// together with all of the intermediate values.
+func DeriveAddressVerbose(spec *AddressSpec) (*AddressDerivation, error) {
+	var (
+		ctx  address.Context
+		data []byte
+	)
+	switch {
+	case spec.Signature != nil:
+		ctx, data = addressContextAndData(spec.Signature.PublicKey)
+	case spec.Multisig != nil:
+		ctx, data = AddressV0MultisigContext, cbor.Marshal(spec.Multisig)
+	default:
+		return nil, fmt.Errorf("malformed AddressSpec")
+	}
+
+	return &AddressDerivation{
+		Address:           (Address)(address.NewAddress(ctx, data)),
+		ContextIdentifier: ctx.Identifier,
+		ContextVersion:    ctx.Version,
+		Data:              data,
+		Hash:              hash.NewFromBytes([]byte(ctx.Identifier), []byte{ctx.Version}, data),
+	}, nil
+}
@@ -1,10 +1,13 @@
 package types
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
 )
 
@@ -55,3 +58,84 @@ func TestAddressMultisig(t *testing.T) {
 
 	require.EqualValues("oasis1qpcprk8jxpsjxw9fadxvzrv9ln7td69yus8rmtux", addr.String())
 }
+
+func TestAddressModule(t *testing.T) {
+	require := require.New(t)
+
+	addr := NewAddressForModule("accounts", "fee-accumulator")
+	require.EqualValues("oasis1qp3r8hgsnphajmfzfuaa8fhjag7e0yt35cjxq0u4", addr.String())
+
+	addr = NewAddressForModule("accounts", "common-pool")
+	require.EqualValues("oasis1qz78phkdan64g040cvqvqpwkplfqf6tj6uwcsh30", addr.String())
+}
+
+func TestDeriveAddressVerbose(t *testing.T) {
+	require := require.New(t)
+
+	pk := ed25519.NewPublicKey("utrdHlX///////////////////////////////////8=")
+	d, err := DeriveAddressVerbose(&AddressSpec{Signature: &PublicKey{PublicKey: pk}})
+	require.NoError(err, "DeriveAddressVerbose")
+	require.EqualValues("oasis1qryqqccycvckcxp453tflalujvlf78xymcdqw4vz", d.Address.String())
+	require.EqualValues(AddressV0Ed25519Context.Identifier, d.ContextIdentifier)
+	require.EqualValues(AddressV0Ed25519Context.Version, d.ContextVersion)
+	rawPk, _ := pk.MarshalBinary()
+	require.EqualValues(rawPk, d.Data)
+	require.EqualValues(d.ContextVersion, d.Address[0], "address should start with the context version")
+	require.EqualValues(d.Hash[:len(d.Address)-1], d.Address[1:], "address should contain the truncated hash")
+
+	config := &MultisigConfig{
+		Signers: []MultisigSigner{
+			{PublicKey: PublicKey{PublicKey: pk}, Weight: 1},
+		},
+		Threshold: 1,
+	}
+	d, err = DeriveAddressVerbose(&AddressSpec{Multisig: config})
+	require.NoError(err, "DeriveAddressVerbose")
+	require.EqualValues(NewAddressFromMultisig(config), d.Address)
+	require.EqualValues(AddressV0MultisigContext.Identifier, d.ContextIdentifier)
+
+	// Keys decoded from CBOR and JSON are pointers.
+	var decoded AddressSpec
+	err = cbor.Unmarshal(cbor.Marshal(NewAddressSpecSignature(pk)), &decoded)
+	require.NoError(err, "cbor.Unmarshal")
+	d, err = DeriveAddressVerbose(&decoded)
+	require.NoError(err, "DeriveAddressVerbose with CBOR-decoded key")
+	require.EqualValues("oasis1qryqqccycvckcxp453tflalujvlf78xymcdqw4vz", d.Address.String())
+	require.EqualValues(rawPk, d.Data)
+
+	var decodedJSON AddressSpec
+	err = json.Unmarshal([]byte(`{"signature":{"ed25519":"utrdHlX///////////////////////////////////8="}}`), &decodedJSON)
+	require.NoError(err, "json.Unmarshal")
+	d, err = DeriveAddressVerbose(&decodedJSON)
+	require.NoError(err, "DeriveAddressVerbose with JSON-decoded key")
+	require.EqualValues("oasis1qryqqccycvckcxp453tflalujvlf78xymcdqw4vz", d.Address.String())
+
+	_, err = DeriveAddressVerbose(&AddressSpec{})
+	require.Error(err, "DeriveAddressVerbose should fail for malformed AddressSpec")
+}
+
+func TestAddressSort(t *testing.T) {
+	require := require.New(t)
+
+	var addrs []Address
+	for _, raw := range []string{
+		"oasis1qryqqccycvckcxp453tflalujvlf78xymcdqw4vz",
+		"oasis1qr4cd0sr32m3xcez37ym7rmjp5g88muu8sdfx8u3",
+		"oasis1qpupfu7e2n6pkezeaw0yhj8mcem8anj64ytrayne",
+	} {
+		var addr Address
+		require.NoError(addr.UnmarshalText([]byte(raw)), "UnmarshalText")
+		addrs = append(addrs, addr)
+	}
+
+	require.False(addrs[0].Less(addrs[0]), "address should not be less than itself")
+	require.True(addrs[0].Less(addrs[1]))
+	require.False(addrs[1].Less(addrs[0]))
+
+	SortAddresses(addrs)
+	require.EqualValues([]string{
+		"oasis1qpupfu7e2n6pkezeaw0yhj8mcem8anj64ytrayne",
+		"oasis1qryqqccycvckcxp453tflalujvlf78xymcdqw4vz",
+		"oasis1qr4cd0sr32m3xcez37ym7rmjp5g88muu8sdfx8u3",
+	}, []string{addrs[0].String(), addrs[1].String(), addrs[2].String()})
+}
@@ -0,0 +1,24 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+// ValidateCanonicalCBOR checks that the given data is canonically CBOR-encoded (e.g., that all
+// map keys are canonically ordered and that all integers use the shortest encoding).
+//
+// This is useful for tooling that receives externally-built transactions and must ensure that
+// they will be verified identically by the runtime.
+func ValidateCanonicalCBOR(data []byte) error {
+	var v interface{}
+	if err := cbor.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("cbor: malformed data: %w", err)
+	}
+	if !bytes.Equal(cbor.Marshal(v), data) {
+		return fmt.Errorf("cbor: data is not canonically encoded")
+	}
+	return nil
+}
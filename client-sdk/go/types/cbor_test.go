@@ -0,0 +1,32 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+func TestValidateCanonicalCBOR(t *testing.T) {
+	require := require.New(t)
+
+	// {"a": 2, "b": 1}
+	err := ValidateCanonicalCBOR([]byte{0xa2, 0x61, 0x61, 0x02, 0x61, 0x62, 0x01})
+	require.NoError(err, "canonically ordered map keys should be accepted")
+
+	// {"b": 1, "a": 2}
+	err = ValidateCanonicalCBOR([]byte{0xa2, 0x61, 0x62, 0x01, 0x61, 0x61, 0x02})
+	require.Error(err, "non-canonically ordered map keys should be rejected")
+
+	// 1 encoded using a non-minimal length.
+	err = ValidateCanonicalCBOR([]byte{0x18, 0x01})
+	require.Error(err, "non-minimal integer encoding should be rejected")
+
+	err = ValidateCanonicalCBOR([]byte{0xa2})
+	require.Error(err, "malformed data should be rejected")
+
+	tx := NewTransaction(&Fee{Gas: 1000}, "accounts.Transfer", map[string]interface{}{"to": "foo"})
+	err = ValidateCanonicalCBOR(cbor.Marshal(tx))
+	require.NoError(err, "marshalled transactions should be canonical")
+}
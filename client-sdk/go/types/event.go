@@ -0,0 +1,39 @@
+package types
+
+import (
+	fxcbor "github.com/fxamacker/cbor/v2"
+)
+
+var (
+	// lenientEventDecMode is the CBOR decoding mode used for lenient event decoding. It matches
+	// the Oasis Core decoding options except that unknown fields are ignored.
+	lenientEventDecMode = mustEventDecMode(fxcbor.ExtraDecErrorNone)
+	// strictEventDecMode is the CBOR decoding mode used for strict event decoding.
+	strictEventDecMode = mustEventDecMode(fxcbor.ExtraDecErrorUnknownField)
+)
+
+func mustEventDecMode(extraErrors fxcbor.ExtraDecErrorCond) fxcbor.DecMode {
+	dm, err := fxcbor.DecOptions{
+		DupMapKey:         fxcbor.DupMapKeyEnforcedAPF,
+		IndefLength:       fxcbor.IndefLengthForbidden,
+		TagsMd:            fxcbor.TagsForbidden,
+		ExtraReturnErrors: extraErrors,
+	}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return dm
+}
+
+// UnmarshalEvent decodes a CBOR-encoded event value into v.
+//
+// By default decoding is lenient and any unknown fields (e.g., added to the event payload by a
+// newer runtime version) are ignored while known fields are filled in. In strict mode (intended
+// for tests), an error is returned in case the value contains any fields that do not correspond
+// to a field of v, including in nested structures.
+func UnmarshalEvent(data []byte, v interface{}, strict bool) error {
+	if strict {
+		return strictEventDecMode.Unmarshal(data, v)
+	}
+	return lenientEventDecMode.Unmarshal(data, v)
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+func TestUnmarshalEvent(t *testing.T) {
+	require := require.New(t)
+
+	type eventV1 struct {
+		A uint64 `json:"a"`
+	}
+	type eventV2 struct {
+		A uint64 `json:"a"`
+		B string `json:"b"`
+	}
+
+	raw := cbor.Marshal(&eventV2{A: 42, B: "new"})
+
+	var ev eventV1
+	err := UnmarshalEvent(raw, &ev, false)
+	require.NoError(err, "lenient decoding should ignore unknown fields")
+	require.EqualValues(42, ev.A)
+
+	err = UnmarshalEvent(raw, &ev, true)
+	require.Error(err, "strict decoding should reject unknown fields")
+
+	err = UnmarshalEvent(cbor.Marshal(&eventV1{A: 42}), &ev, true)
+	require.NoError(err, "strict decoding should accept exact values")
+}
+
+func TestUnmarshalEventStrictNested(t *testing.T) {
+	require := require.New(t)
+
+	type innerV1 struct {
+		X uint64 `json:"x"`
+	}
+	type innerV2 struct {
+		X uint64 `json:"x"`
+		Y uint64 `json:"y,omitempty"`
+	}
+	type eventV1 struct {
+		Inner  innerV1   `json:"inner"`
+		List   []innerV1 `json:"list,omitempty"`
+		Amount BaseUnits `json:"amount"`
+	}
+	type eventV2 struct {
+		Inner  innerV2   `json:"inner"`
+		List   []innerV2 `json:"list,omitempty"`
+		Amount BaseUnits `json:"amount"`
+	}
+	amount := NewBaseUnits(*quantity.NewFromUint64(10), NativeDenomination)
+
+	var ev eventV1
+	err := UnmarshalEvent(cbor.Marshal(&eventV2{Inner: innerV2{X: 1}, Amount: amount}), &ev, true)
+	require.NoError(err, "strict decoding should accept omitted zero values")
+
+	err = UnmarshalEvent(cbor.Marshal(&eventV2{Inner: innerV2{X: 1, Y: 2}, Amount: amount}), &ev, true)
+	require.Error(err, "strict decoding should reject unknown nested fields")
+
+	err = UnmarshalEvent(cbor.Marshal(&eventV2{List: []innerV2{{X: 1}, {X: 2, Y: 3}}, Amount: amount}), &ev, true)
+	require.Error(err, "strict decoding should reject unknown fields in lists")
+
+	err = UnmarshalEvent(cbor.Marshal(&eventV2{List: []innerV2{{X: 1, Y: 3}}, Amount: amount}), &ev, false)
+	require.NoError(err, "lenient decoding should ignore unknown nested fields")
+	require.EqualValues(1, ev.List[0].X)
+}
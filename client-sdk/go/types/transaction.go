@@ -22,6 +22,46 @@ type AuthProof struct {
 	Signature []byte `json:"signature,omitempty"`
 	// Multisig is for multisig authentication.
 	Multisig [][]byte `json:"multisig,omitempty"`
+	// Module is for module-controlled authentication. The string is the name of an encoding
+	// scheme that a module must handle and must not be empty.
+	//
+	// Note that runtimes based on this version of the SDK do not support module-controlled
+	// authentication and reject transactions using it.
+	Module string `json:"module,omitempty"`
+}
+
+// IsSignature returns true iff the proof is a signature proof.
+func (ap *AuthProof) IsSignature() bool {
+	return ap.Signature != nil
+}
+
+// IsMultisig returns true iff the proof is a multisig proof.
+func (ap *AuthProof) IsMultisig() bool {
+	return ap.Multisig != nil
+}
+
+// IsModuleAuthenticated returns the name of the module-controlled authentication scheme and true
+// iff the proof is a module-controlled authentication proof.
+func (ap *AuthProof) IsModuleAuthenticated() (string, bool) {
+	return ap.Module, ap.Module != ""
+}
+
+// SignatureCount returns the number of signatures included in the proof.
+func (ap *AuthProof) SignatureCount() int {
+	switch {
+	case ap.Signature != nil:
+		return 1
+	case ap.Multisig != nil:
+		var count int
+		for _, sig := range ap.Multisig {
+			if sig != nil {
+				count++
+			}
+		}
+		return count
+	default:
+		return 0
+	}
 }
 
 // UnverifiedTransaction is an unverified transaction.
@@ -161,6 +201,14 @@ func (t *Transaction) ValidateBasic() error {
 	return nil
 }
 
+// EncodedSize returns the size of the CBOR-encoded transaction body in bytes.
+//
+// Note that the size of the submitted (unverified) transaction is larger as it additionally
+// includes the authentication proofs.
+func (t *Transaction) EncodedSize() int {
+	return len(cbor.Marshal(t))
+}
+
 // AppendSignerInfo appends a new transaction signer information to the transaction.
 func (t *Transaction) AppendSignerInfo(addressSpec AddressSpec, nonce uint64) {
 	t.AuthInfo.SignerInfo = append(t.AuthInfo.SignerInfo, SignerInfo{
@@ -172,13 +220,19 @@ func (t *Transaction) AppendSignerInfo(addressSpec AddressSpec, nonce uint64) {
 // AppendAuthSignature appends a new transaction signer information with a signature address
 // specification to the transaction.
 func (t *Transaction) AppendAuthSignature(pk signature.PublicKey, nonce uint64) {
-	t.AppendSignerInfo(AddressSpec{Signature: &PublicKey{PublicKey: pk}}, nonce)
+	t.AppendSignerInfo(NewAddressSpecSignature(pk), nonce)
 }
 
 // AppendAuthMultisig appends a new transaction signer information with a multisig address
 // specification to the transaction.
 func (t *Transaction) AppendAuthMultisig(config *MultisigConfig, nonce uint64) {
-	t.AppendSignerInfo(AddressSpec{Multisig: config}, nonce)
+	t.AppendSignerInfo(NewAddressSpecMultisig(config), nonce)
+}
+
+// AppendAuthAccount appends a new transaction signer information with the given account's
+// signature address specification to the transaction.
+func (t *Transaction) AppendAuthAccount(account *Account, nonce uint64) {
+	t.AppendSignerInfo(account.AddressSpec, nonce)
 }
 
 func (t *Transaction) PrepareForSigning() *TransactionSigner {
@@ -220,12 +274,80 @@ type AuthInfo struct {
 	Fee        Fee          `json:"fee"`
 }
 
+// SignerCount returns the number of signers (signature or multisig address specifications).
+func (ai *AuthInfo) SignerCount() int {
+	return len(ai.SignerInfo)
+}
+
+// IsMultisig returns true iff any of the signers uses a multisig address specification.
+func (ai *AuthInfo) IsMultisig() bool {
+	for _, si := range ai.SignerInfo {
+		if si.AddressSpec.Multisig != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // Fee contains the transaction fee information.
 type Fee struct {
 	Amount BaseUnits `json:"amount"`
 	Gas    uint64    `json:"gas"`
 }
 
+// GasPrice returns the gas price implied by the fee amount and gas limit.
+//
+// In case the gas limit is zero, the returned price is zero.
+func (f *Fee) GasPrice() *Quantity {
+	if f.Gas == 0 {
+		return quantity.NewQuantity()
+	}
+	price := f.Amount.Amount.Clone()
+	_ = price.Quo(quantity.NewFromUint64(f.Gas))
+	return price
+}
+
+// ValidateMinGasPrice checks that the gas price implied by the fee is at least the given
+// minimum gas price.
+func (f *Fee) ValidateMinGasPrice(minPrice *Quantity) error {
+	if price := f.GasPrice(); price.Cmp(minPrice) < 0 {
+		return fmt.Errorf("fee: gas price %s is below minimum gas price %s", price, minPrice)
+	}
+	return nil
+}
+
+// NewFeeWithBudget creates a new fee that never spends more than the given total amount.
+//
+// The gas price is computed as maxTotal/gas (rounded down) and the fee amount is set to the
+// gas price multiplied by the gas limit so that the resulting amount never exceeds the budget.
+// In case the runtime's minimum gas price is known, the returned fee should additionally be
+// checked via ValidateMinGasPrice.
+//
+// An error is returned in case a non-zero budget is too small to pay a gas price of at least one
+// base unit per unit of gas.
+func NewFeeWithBudget(maxTotal BaseUnits, gas uint64) (*Fee, error) {
+	if gas == 0 {
+		return nil, fmt.Errorf("fee: gas limit must be non-zero")
+	}
+
+	qGas := quantity.NewFromUint64(gas)
+	amount := maxTotal.Amount.Clone()
+	if err := amount.Quo(qGas); err != nil {
+		return nil, fmt.Errorf("fee: failed to compute gas price: %w", err)
+	}
+	if amount.IsZero() && !maxTotal.Amount.IsZero() {
+		return nil, fmt.Errorf("fee: budget %s is too small for gas limit %d (gas price rounds to zero)", maxTotal.Amount.String(), gas)
+	}
+	if err := amount.Mul(qGas); err != nil {
+		return nil, fmt.Errorf("fee: failed to compute fee amount: %w", err)
+	}
+
+	return &Fee{
+		Amount: NewBaseUnits(*amount, maxTotal.Denomination),
+		Gas:    gas,
+	}, nil
+}
+
 // AddressSpec is common information that specifies an address as well as how to authenticate.
 type AddressSpec struct {
 	// Signature is for signature authentication.
@@ -234,11 +356,39 @@ type AddressSpec struct {
 	Multisig *MultisigConfig `json:"multisig,omitempty"`
 }
 
+// NewAddressSpecSignature creates a new signature address specification for the given public key.
+func NewAddressSpecSignature(pk signature.PublicKey) AddressSpec {
+	return AddressSpec{Signature: &PublicKey{PublicKey: pk}}
+}
+
+// NewAddressSpecMultisig creates a new multisig address specification for the given
+// configuration.
+func NewAddressSpecMultisig(config *MultisigConfig) AddressSpec {
+	return AddressSpec{Multisig: config}
+}
+
+// PublicKeys returns the public keys of all signers that can authenticate for this address
+// specification, irrespective of the signature scheme.
+func (as *AddressSpec) PublicKeys() ([]PublicKey, error) {
+	switch {
+	case as.Signature != nil:
+		return []PublicKey{*as.Signature}, nil
+	case as.Multisig != nil:
+		pks := make([]PublicKey, 0, len(as.Multisig.Signers))
+		for _, signer := range as.Multisig.Signers {
+			pks = append(pks, signer.PublicKey)
+		}
+		return pks, nil
+	default:
+		return nil, fmt.Errorf("malformed AddressSpec")
+	}
+}
+
 // Address derives the address.
 func (as *AddressSpec) Address() (Address, error) {
 	switch {
 	case as.Signature != nil:
-		return NewAddress(as.Signature), nil
+		return NewAddress(as.Signature.PublicKey), nil
 	case as.Multisig != nil:
 		return NewAddressFromMultisig(as.Multisig), nil
 	default:
@@ -283,6 +433,12 @@ type FailedCallResult struct {
 	Message string `json:"message,omitempty"`
 }
 
+// IsError checks whether the failed call result was caused by the given error of the given
+// module. It can be used to branch on specific failures instead of matching the message.
+func (cr FailedCallResult) IsError(module string, code uint32) bool {
+	return cr.Module == module && cr.Code == code
+}
+
 // Error is a trivial implementation of error.
 func (cr FailedCallResult) Error() string {
 	return cr.String()
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -8,6 +9,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
@@ -40,6 +42,18 @@ func TestTransactionBasicValidation(t *testing.T) {
 	}
 }
 
+func TestTransactionEncodedSize(t *testing.T) {
+	require := require.New(t)
+
+	tx := NewTransaction(nil, "hello.World", []byte("body"))
+	size := tx.EncodedSize()
+	require.EqualValues(len(cbor.Marshal(tx)), size, "encoded size should match")
+
+	oldBodySize := len(tx.Call.Body)
+	tx.Call.Body = cbor.Marshal(make([]byte, 1024))
+	require.EqualValues(size-oldBodySize+len(tx.Call.Body), tx.EncodedSize(), "encoded size should grow with the body")
+}
+
 func TestTransactionSigning(t *testing.T) {
 	require := require.New(t)
 
@@ -76,4 +90,115 @@ func TestTransactionSigning(t *testing.T) {
 	require.NoError(err, "Verify")
 	err = tx.ValidateBasic()
 	require.NoError(err, "ValidateBasic")
+
+	require.EqualValues(3, tx.AuthInfo.SignerCount(), "SignerCount")
+	require.True(tx.AuthInfo.IsMultisig(), "IsMultisig")
+	require.Len(ut.AuthProofs, 3)
+	require.True(ut.AuthProofs[0].IsSignature(), "IsSignature")
+	require.False(ut.AuthProofs[0].IsMultisig(), "IsMultisig")
+	require.EqualValues(1, ut.AuthProofs[0].SignatureCount(), "SignatureCount")
+	require.False(ut.AuthProofs[2].IsSignature(), "IsSignature")
+	require.True(ut.AuthProofs[2].IsMultisig(), "IsMultisig")
+	require.EqualValues(2, ut.AuthProofs[2].SignatureCount(), "SignatureCount")
+	_, ok := ut.AuthProofs[0].IsModuleAuthenticated()
+	require.False(ok, "IsModuleAuthenticated")
+
+	moduleProof := AuthProof{Module: "evm.ethereum.v0"}
+	scheme, ok := moduleProof.IsModuleAuthenticated()
+	require.True(ok, "IsModuleAuthenticated")
+	require.Equal("evm.ethereum.v0", scheme)
+	require.False(moduleProof.IsSignature(), "IsSignature")
+	require.False(moduleProof.IsMultisig(), "IsMultisig")
+	require.EqualValues(0, moduleProof.SignatureCount(), "SignatureCount")
+
+	ut.AuthProofs[0] = moduleProof
+	_, err = ut.Verify(chainCtx)
+	require.Error(err, "Verify should reject module-controlled authentication")
+}
+
+func TestNewFeeWithBudget(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewFeeWithBudget(NewBaseUnits(*quantity.NewFromUint64(1000), NativeDenomination), 0)
+	require.Error(err, "NewFeeWithBudget should fail with zero gas")
+	_, err = NewFeeWithBudget(NewBaseUnits(*quantity.NewFromUint64(99), NativeDenomination), 100)
+	require.Error(err, "NewFeeWithBudget should fail when the gas price rounds to zero")
+
+	for _, tc := range []struct {
+		budget         uint64
+		gas            uint64
+		expectedAmount uint64
+		expectedPrice  uint64
+	}{
+		{1000, 100, 1000, 10},
+		{1050, 100, 1000, 10},
+		{0, 100, 0, 0},
+	} {
+		fee, err := NewFeeWithBudget(NewBaseUnits(*quantity.NewFromUint64(tc.budget), Denomination("test")), tc.gas)
+		require.NoError(err, "NewFeeWithBudget")
+		require.EqualValues(tc.gas, fee.Gas)
+		require.EqualValues(Denomination("test"), fee.Amount.Denomination)
+		require.Zero(fee.Amount.Amount.Cmp(quantity.NewFromUint64(tc.expectedAmount)), "fee amount should match")
+		require.Zero(fee.GasPrice().Cmp(quantity.NewFromUint64(tc.expectedPrice)), "gas price should match")
+	}
+
+	fee, err := NewFeeWithBudget(NewBaseUnits(*quantity.NewFromUint64(1000), NativeDenomination), 100)
+	require.NoError(err, "NewFeeWithBudget")
+	require.NoError(fee.ValidateMinGasPrice(quantity.NewFromUint64(10)), "gas price at minimum")
+	require.Error(fee.ValidateMinGasPrice(quantity.NewFromUint64(11)), "gas price below minimum")
+}
+
+func TestAddressSpecPublicKeys(t *testing.T) {
+	require := require.New(t)
+
+	pkA := ed25519.NewPublicKey("CgAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=")
+	pkB := ed25519.NewPublicKey("CwAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=")
+
+	spec := NewAddressSpecSignature(pkA)
+	pks, err := spec.PublicKeys()
+	require.NoError(err, "PublicKeys")
+	require.Len(pks, 1)
+	require.True(pks[0].Equal(pkA), "public key should match")
+
+	var decSpec AddressSpec
+	err = cbor.Unmarshal(cbor.Marshal(spec), &decSpec)
+	require.NoError(err, "AddressSpec should round-trip")
+	pks, err = decSpec.PublicKeys()
+	require.NoError(err, "PublicKeys")
+	require.True(pks[0].Equal(pkA), "decoded public key should match")
+
+	spec = NewAddressSpecMultisig(&MultisigConfig{
+		Signers: []MultisigSigner{
+			{PublicKey: PublicKey{PublicKey: pkA}, Weight: 1},
+			{PublicKey: PublicKey{PublicKey: pkB}, Weight: 1},
+		},
+		Threshold: 2,
+	})
+	pks, err = spec.PublicKeys()
+	require.NoError(err, "PublicKeys")
+	require.Len(pks, 2)
+	require.True(pks[0].Equal(pkA), "first public key should match")
+	require.True(pks[1].Equal(pkB), "second public key should match")
+
+	_, err = (&AddressSpec{}).PublicKeys()
+	require.Error(err, "PublicKeys should fail for malformed AddressSpec")
+}
+
+func TestFailedCallResultIsError(t *testing.T) {
+	require := require.New(t)
+
+	var err error = &FailedCallResult{Module: "accounts", Code: 2, Message: "insufficient balance"}
+	var fcr *FailedCallResult
+	require.True(errors.As(err, &fcr), "FailedCallResult should be usable as an error")
+	require.True(fcr.IsError("accounts", 2))
+	require.False(fcr.IsError("accounts", 3), "different code should not match")
+	require.False(fcr.IsError("core", 2), "different module should not match")
+}
+
+func TestSignatureContextBaseReserved(t *testing.T) {
+	require := require.New(t)
+
+	// The transaction signing context must not be usable as a raw context.
+	_, err := signature.NewRawContextSigner(string(SignatureContextBase))
+	require.Error(err, "transaction signing context should be reserved")
 }
@@ -1,10 +1,15 @@
 //! Accounts module.
-use std::{collections::BTreeMap, convert::TryInto};
+use std::{
+    collections::BTreeMap,
+    convert::{TryFrom, TryInto},
+};
 
 use num_traits::Zero;
 use once_cell::sync::Lazy;
 use thiserror::Error;
 
+use oasis_core_runtime::storage::mkvs;
+
 use crate::{
     context::{Context, TxContext},
     crypto::signature::PublicKey,
@@ -412,6 +417,49 @@ impl Module {
     ) -> Result<types::AccountBalances, Error> {
         Self::get_balances(ctx.runtime_state(), args.address)
     }
+
+    fn query_addresses<C: Context>(
+        ctx: &mut C,
+        args: types::AddressesQuery,
+    ) -> Result<Vec<Address>, Error> {
+        let store = storage::PrefixStore::new(ctx.runtime_state(), &MODULE_NAME);
+        let balances = storage::PrefixStore::new(store, &state::BALANCES);
+        let limit = match args.limit {
+            0 => usize::MAX,
+            limit => limit.try_into().unwrap_or(usize::MAX),
+        };
+
+        // Balances are keyed by address followed by denomination so we can start iterating at
+        // the cursor instead of scanning all balances.
+        let mut it = storage::Store::iter(&balances);
+        if let Some(after) = &args.after {
+            mkvs::Iterator::seek(&mut *it, after.as_ref());
+        }
+
+        let mut addresses = Vec::new();
+        for (key, _) in it {
+            if addresses.len() >= limit {
+                break;
+            }
+
+            let AddressWithDenomination(address, denomination) =
+                AddressWithDenomination::try_from(&key[..])
+                    .unwrap_or_else(|e| panic!("corrupted storage key: {}", e));
+            if Some(&address) == args.after.as_ref() || denomination != args.denomination {
+                continue;
+            }
+            addresses.push(address);
+        }
+
+        Ok(addresses)
+    }
+
+    fn query_total_supplies<C: Context>(
+        ctx: &mut C,
+        _args: (),
+    ) -> Result<BTreeMap<token::Denomination, u128>, Error> {
+        Self::get_total_supplies(ctx.runtime_state())
+    }
 }
 
 impl module::Module for Module {
@@ -456,6 +504,14 @@ impl module::MethodHandler for Module {
                 let args = cbor::from_value(args).map_err(|_| Error::InvalidArgument)?;
                 Ok(cbor::to_value(Self::query_balances(ctx, args)?))
             })()),
+            "accounts.Addresses" => module::DispatchResult::Handled((|| {
+                let args = cbor::from_value(args).map_err(|_| Error::InvalidArgument)?;
+                Ok(cbor::to_value(Self::query_addresses(ctx, args)?))
+            })()),
+            "accounts.TotalSupplies" => module::DispatchResult::Handled((|| {
+                let args = cbor::from_value(args).map_err(|_| Error::InvalidArgument)?;
+                Ok(cbor::to_value(Self::query_total_supplies(ctx, args)?))
+            })()),
             _ => module::DispatchResult::Unhandled(args),
         }
     }
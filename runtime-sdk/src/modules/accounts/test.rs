@@ -7,6 +7,7 @@ use crate::{
     modules::core,
     testing::{keys, mock},
     types::{
+        address::Address,
         token::{BaseUnits, Denomination},
         transaction,
     },
@@ -836,3 +837,184 @@ fn test_check_invariants_more() {
         "inv chk 6 should succeed"
     );
 }
+
+#[test]
+fn test_query_addresses_and_total_supplies() {
+    let mut mock = mock::Mock::default();
+    let mut ctx = mock.create_ctx();
+
+    let alice = keys::alice::address();
+    let bob = keys::bob::address();
+    let charlie = keys::charlie::address();
+    let d1: Denomination = "den1".parse().unwrap();
+
+    Accounts::init(
+        &mut ctx,
+        Genesis {
+            balances: {
+                let mut balances = BTreeMap::new();
+                balances.insert(alice, {
+                    let mut denominations = BTreeMap::new();
+                    denominations.insert(Denomination::NATIVE, 1_000_000);
+                    denominations
+                });
+                balances.insert(bob, {
+                    let mut denominations = BTreeMap::new();
+                    denominations.insert(Denomination::NATIVE, 2_000_000);
+                    denominations.insert(d1.clone(), 10);
+                    denominations
+                });
+                balances.insert(charlie, {
+                    let mut denominations = BTreeMap::new();
+                    denominations.insert(d1.clone(), 5);
+                    denominations
+                });
+                balances
+            },
+            total_supplies: {
+                let mut total_supplies = BTreeMap::new();
+                total_supplies.insert(Denomination::NATIVE, 3_000_000);
+                total_supplies.insert(d1.clone(), 15);
+                total_supplies
+            },
+            ..Default::default()
+        },
+    );
+
+    let query = |denomination: &Denomination, after, limit| AddressesQuery {
+        denomination: denomination.clone(),
+        after,
+        limit,
+    };
+
+    // Addresses are returned in address order.
+    let mut expected = vec![alice, bob];
+    expected.sort();
+    let addrs = Accounts::query_addresses(&mut ctx, query(&Denomination::NATIVE, None, 0))
+        .expect("query_addresses should succeed");
+    assert_eq!(
+        addrs, expected,
+        "all native token holders should be returned"
+    );
+
+    let addrs = Accounts::query_addresses(&mut ctx, query(&Denomination::NATIVE, None, 1))
+        .expect("query_addresses should succeed");
+    assert_eq!(addrs, expected[..1], "first page should be returned");
+    let addrs =
+        Accounts::query_addresses(&mut ctx, query(&Denomination::NATIVE, Some(expected[0]), 1))
+            .expect("query_addresses should succeed");
+    assert_eq!(addrs, expected[1..], "second page should be returned");
+    let addrs =
+        Accounts::query_addresses(&mut ctx, query(&Denomination::NATIVE, Some(expected[1]), 1))
+            .expect("query_addresses should succeed");
+    assert!(addrs.is_empty(), "pages past the end should be empty");
+
+    let mut expected = vec![bob, charlie];
+    expected.sort();
+    let addrs = Accounts::query_addresses(&mut ctx, query(&d1, None, 0))
+        .expect("query_addresses should succeed");
+    assert_eq!(addrs, expected, "all den1 holders should be returned");
+
+    let ts =
+        Accounts::query_total_supplies(&mut ctx, ()).expect("query_total_supplies should succeed");
+    assert_eq!(ts.len(), 2, "two denominations should be present");
+    assert_eq!(ts[&Denomination::NATIVE], 3_000_000);
+    assert_eq!(ts[&d1], 15);
+}
+
+#[test]
+fn test_query_addresses_pagination() {
+    let mut mock = mock::Mock::default();
+    let mut ctx = mock.create_ctx();
+
+    let d1: Denomination = "den1".parse().unwrap();
+    let addr = |b: u8| Address::from_bytes(&[b; Address::SIZE]).unwrap();
+
+    // Native token holders, interleaved with accounts holding multiple denominations and an
+    // account holding only another denomination.
+    let holders: Vec<Address> = (1..=5).map(|i| addr(2 * i)).collect();
+    let other = addr(5);
+
+    Accounts::init(
+        &mut ctx,
+        Genesis {
+            balances: {
+                let mut balances = BTreeMap::new();
+                for (i, holder) in holders.iter().enumerate() {
+                    let mut denominations = BTreeMap::new();
+                    denominations.insert(Denomination::NATIVE, 100);
+                    if i % 2 == 0 {
+                        denominations.insert(d1.clone(), 1);
+                    }
+                    balances.insert(*holder, denominations);
+                }
+                balances.insert(other, {
+                    let mut denominations = BTreeMap::new();
+                    denominations.insert(d1.clone(), 1);
+                    denominations
+                });
+                balances
+            },
+            total_supplies: {
+                let mut total_supplies = BTreeMap::new();
+                total_supplies.insert(Denomination::NATIVE, 500);
+                total_supplies.insert(d1.clone(), 4);
+                total_supplies
+            },
+            ..Default::default()
+        },
+    );
+
+    let query = |after, limit| AddressesQuery {
+        denomination: Denomination::NATIVE,
+        after,
+        limit,
+    };
+
+    // Paginate using the last address of each page as the cursor.
+    let mut pages = Vec::new();
+    let mut after = None;
+    loop {
+        let page = Accounts::query_addresses(&mut ctx, query(after, 2))
+            .expect("query_addresses should succeed");
+        if page.is_empty() {
+            break;
+        }
+        after = page.last().copied();
+        pages.push(page);
+    }
+    assert_eq!(
+        pages,
+        vec![
+            holders[0..2].to_vec(),
+            holders[2..4].to_vec(),
+            holders[4..].to_vec()
+        ],
+        "pages should not overlap or skip addresses"
+    );
+
+    // A page that exactly exhausts the remaining addresses is followed by an empty page.
+    let addrs = Accounts::query_addresses(&mut ctx, query(Some(holders[2]), 2))
+        .expect("query_addresses should succeed");
+    assert_eq!(addrs, holders[3..], "last full page should be returned");
+    let addrs = Accounts::query_addresses(&mut ctx, query(Some(holders[4]), 2))
+        .expect("query_addresses should succeed");
+    assert!(
+        addrs.is_empty(),
+        "page after the last address should be empty"
+    );
+
+    // The cursor need not hold the queried denomination (or any balance at all).
+    let addrs = Accounts::query_addresses(&mut ctx, query(Some(other), 0))
+        .expect("query_addresses should succeed");
+    assert_eq!(addrs, holders[2..], "cursor holding another denomination");
+    let addrs = Accounts::query_addresses(&mut ctx, query(Some(addr(7)), 1))
+        .expect("query_addresses should succeed");
+    assert_eq!(addrs, holders[3..4], "cursor without any balances");
+    let addrs = Accounts::query_addresses(&mut ctx, query(Some(addr(0)), 1))
+        .expect("query_addresses should succeed");
+    assert_eq!(addrs, holders[..1], "cursor before all addresses");
+    let addrs = Accounts::query_addresses(&mut ctx, query(Some(addr(0xff)), 0))
+        .expect("query_addresses should succeed");
+    assert!(addrs.is_empty(), "cursor after all addresses");
+}
@@ -33,6 +33,25 @@ pub struct BalancesQuery {
     pub address: Address,
 }
 
+/// Arguments for the Addresses query.
+#[derive(Clone, Debug, cbor::Encode, cbor::Decode)]
+pub struct AddressesQuery {
+    pub denomination: token::Denomination,
+
+    /// Only return addresses strictly after this address (in address order). This should be set
+    /// to the last address of the previous page when paginating.
+    #[cbor(optional)]
+    #[cbor(default)]
+    #[cbor(skip_serializing_if = "Option::is_none")]
+    pub after: Option<Address>,
+
+    /// Maximum number of addresses to return. Zero means no limit.
+    #[cbor(optional)]
+    #[cbor(default)]
+    #[cbor(skip_serializing_if = "Zero::is_zero")]
+    pub limit: u64,
+}
+
 /// Balances in an account.
 #[derive(Clone, Debug, cbor::Encode, cbor::Decode)]
 pub struct AccountBalances {
@@ -5,8 +5,10 @@ use thiserror::Error;
 
 use crate::{
     context::{BatchContext, Context, TxContext},
+    core::consensus::beacon,
     dispatcher, error,
     module::{self, InvariantHandler as _, Module as _},
+    storage,
     types::transaction::{
         self, AddressSpec, AuthProof, Call, TransactionWeight, UnverifiedTransaction,
     },
@@ -275,6 +277,26 @@ impl Module {
     fn query_check_invariants<C: Context>(ctx: &mut C) -> Result<(), Error> {
         <C::Runtime as Runtime>::Modules::check_invariants(ctx)
     }
+
+    /// Return the core module parameters.
+    fn query_parameters<C: Context>(ctx: &mut C, _args: ()) -> Result<Parameters, Error> {
+        Ok(Self::params(ctx.runtime_state()))
+    }
+
+    /// Return the current consensus layer epoch.
+    fn query_epoch<C: Context>(ctx: &mut C, _args: ()) -> Result<beacon::EpochTime, Error> {
+        Ok(ctx.epoch())
+    }
+
+    /// Number of consensus messages emitted by the runtime in the queried round.
+    fn query_emitted_messages<C: Context>(ctx: &mut C, _args: ()) -> Result<u32, Error> {
+        let store =
+            storage::TypedStore::new(storage::PrefixStore::new(ctx.runtime_state(), &MODULE_NAME));
+        // A message handler is stored for each message emitted in the round.
+        let handlers: BTreeMap<u32, crate::types::message::MessageEventHookInvocation> =
+            store.get(&state::MESSAGE_HANDLERS).unwrap_or_default();
+        Ok(handlers.len() as u32)
+    }
 }
 
 impl module::Module for Module {
@@ -368,6 +390,18 @@ impl module::MethodHandler for Module {
                 let _ = Self::query_check_invariants(ctx)?;
                 Ok(cbor::to_value(true))
             })()),
+            "core.Parameters" => module::DispatchResult::Handled((|| {
+                let args = cbor::from_value(args).map_err(|e| Error::InvalidArgument(e.into()))?;
+                Ok(cbor::to_value(Self::query_parameters(ctx, args)?))
+            })()),
+            "core.Epoch" => module::DispatchResult::Handled((|| {
+                let args = cbor::from_value(args).map_err(|e| Error::InvalidArgument(e.into()))?;
+                Ok(cbor::to_value(Self::query_epoch(ctx, args)?))
+            })()),
+            "core.EmittedMessages" => module::DispatchResult::Handled((|| {
+                let args = cbor::from_value(args).map_err(|e| Error::InvalidArgument(e.into()))?;
+                Ok(cbor::to_value(Self::query_emitted_messages(ctx, args)?))
+            })()),
             _ => module::DispatchResult::Unhandled(args),
         }
     }
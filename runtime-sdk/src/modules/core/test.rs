@@ -9,8 +9,9 @@ use crate::{
     dispatcher, module,
     module::{AuthHandler as _, BlockHandler, Module as _},
     runtime::Runtime,
+    storage,
     testing::{keys, mock},
-    types::{token, transaction, transaction::TransactionWeight},
+    types::{message, token, transaction, transaction::TransactionWeight},
 };
 
 use super::{Module as Core, Parameters, API as _, GAS_WEIGHT_NAME};
@@ -393,3 +394,85 @@ fn test_get_batch_weight_limits_query() {
         "querying weights should return correct limits"
     );
 }
+
+#[test]
+fn test_query_epoch() {
+    let mut mock = mock::Mock::default();
+    mock.epoch = 42;
+    let mut ctx = mock.create_ctx();
+
+    let epoch = Core::query_epoch(&mut ctx, ()).expect("query_epoch should succeed");
+    assert_eq!(epoch, 42, "epoch should be correct");
+}
+
+#[test]
+fn test_query_emitted_messages() {
+    let mut mock = mock::Mock::default();
+    let mut ctx = mock.create_ctx();
+
+    let count =
+        Core::query_emitted_messages(&mut ctx, ()).expect("query_emitted_messages should succeed");
+    assert_eq!(count, 0, "no messages should be emitted");
+
+    let handlers: BTreeMap<u32, message::MessageEventHookInvocation> = (0..3)
+        .map(|idx| {
+            (
+                idx,
+                message::MessageEventHookInvocation::new("test".to_string(), ()),
+            )
+        })
+        .collect();
+    let mut store = storage::TypedStore::new(storage::PrefixStore::new(
+        ctx.runtime_state(),
+        &super::MODULE_NAME,
+    ));
+    store.insert(&super::state::MESSAGE_HANDLERS, handlers);
+
+    let count =
+        Core::query_emitted_messages(&mut ctx, ()).expect("query_emitted_messages should succeed");
+    assert_eq!(count, 3, "emitted message count should be correct");
+}
+
+#[test]
+fn test_query_parameters() {
+    let mut mock = mock::Mock::default();
+    let mut ctx = mock.create_ctx_for_runtime::<GasWasterRuntime>(Mode::CheckTx);
+
+    let params = Parameters {
+        max_batch_gas: 1_000,
+        max_tx_signers: 4,
+        max_multisig_signers: 6,
+        gas_costs: super::GasCosts {
+            auth_signature: 10,
+            auth_multisig_signer: 20,
+        },
+    };
+    Core::set_params(ctx.runtime_state(), params.clone());
+
+    let res = Core::query_parameters(&mut ctx, ()).expect("query_parameters should succeed");
+    assert_eq!(res.max_batch_gas, params.max_batch_gas);
+    assert_eq!(res.max_tx_signers, params.max_tx_signers);
+    assert_eq!(res.max_multisig_signers, params.max_multisig_signers);
+    assert_eq!(
+        res.gas_costs.auth_signature,
+        params.gas_costs.auth_signature
+    );
+    assert_eq!(
+        res.gas_costs.auth_multisig_signer,
+        params.gas_costs.auth_multisig_signer
+    );
+
+    // Query through the dispatcher to make sure the method is exposed.
+    let res = dispatcher::Dispatcher::<GasWasterRuntime>::dispatch_query(
+        &mut ctx,
+        "core.Parameters",
+        cbor::Value::Simple(cbor::SimpleValue::NullValue),
+    )
+    .expect("core.Parameters query should work");
+    let res: Parameters = cbor::from_value(res).expect("result should decode");
+    assert_eq!(res.max_batch_gas, params.max_batch_gas);
+    assert_eq!(
+        res.gas_costs.auth_multisig_signer,
+        params.gas_costs.auth_multisig_signer
+    );
+}
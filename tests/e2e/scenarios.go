@@ -23,7 +23,9 @@ var (
 		KVTransferTest,
 		KVDaveTest,
 		KVMultisigTest,
+		KVNonceHistoryTest,
 		KVRewardsTest,
+		KVTransferVerifyTest,
 		KVTxGenTest,
 	})
 
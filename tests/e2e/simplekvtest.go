@@ -72,6 +72,134 @@ func GetChainContext(ctx context.Context, rtc client.RuntimeClient) (signature.C
 	return info.ChainContext, nil
 }
 
+// transferAndVerify transfers the given amount from the signer's account to the given address
+// paying the given fee and verifies that the balances of both accounts and of the fee
+// accumulator changed accordingly in the round in which the transfer was included.
+//
+// As balances are compared across rounds, no other transactions affecting the two accounts or
+// paying fees may be executed concurrently.
+func transferAndVerify(
+	ctx context.Context,
+	rtc client.RuntimeClient,
+	signer signature.Signer,
+	to types.Address,
+	amount types.BaseUnits,
+	fee types.BaseUnits,
+) error {
+	ac := accounts.NewV1(rtc)
+	from := types.NewAddress(signer.Public())
+	if from.Equal(to) {
+		return fmt.Errorf("sender and receiver must be different")
+	}
+
+	chainCtx, err := GetChainContext(ctx, rtc)
+	if err != nil {
+		return err
+	}
+	startBlk, err := rtc.GetBlock(ctx, client.RoundLatest)
+	if err != nil {
+		return err
+	}
+	nonce, err := ac.Nonce(ctx, startBlk.Header.Round, from)
+	if err != nil {
+		return err
+	}
+
+	tx := ac.Transfer(to, amount).GetTransaction()
+	tx.AuthInfo.Fee = types.Fee{Amount: fee, Gas: defaultGasAmount}
+	tx.AppendAuthSignature(signer.Public(), nonce)
+	stx := tx.PrepareForSigning()
+	if err = stx.AppendSign(chainCtx, signer); err != nil {
+		return err
+	}
+	if _, err = rtc.SubmitTx(ctx, stx.UnverifiedTransaction()); err != nil {
+		return fmt.Errorf("failed to submit transfer: %w", err)
+	}
+
+	// Find the round in which the transfer was included by looking for the nonce increase.
+	latestBlk, err := rtc.GetBlock(ctx, client.RoundLatest)
+	if err != nil {
+		return err
+	}
+	var round uint64
+	for r := startBlk.Header.Round + 1; r <= latestBlk.Header.Round; r++ {
+		n, err := ac.Nonce(ctx, r, from)
+		if err != nil {
+			return err
+		}
+		if n > nonce {
+			round = r
+			break
+		}
+	}
+	if round == 0 {
+		return fmt.Errorf("transfer not found in rounds %d-%d", startBlk.Header.Round+1, latestBlk.Header.Round)
+	}
+
+	balanceOf := func(round uint64, addr types.Address, denom types.Denomination) (*quantity.Quantity, error) {
+		b, err := ac.Balances(ctx, round, addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query balances of %s at round %d: %w", addr, round, err)
+		}
+		q := b.Balances[denom]
+		return &q, nil
+	}
+	checkDelta := func(addr types.Address, denom types.Denomination, add, sub []*quantity.Quantity) error {
+		before, err := balanceOf(round-1, addr, denom)
+		if err != nil {
+			return err
+		}
+		after, err := balanceOf(round, addr, denom)
+		if err != nil {
+			return err
+		}
+		expected := before.Clone()
+		for _, q := range add {
+			if err = expected.Add(q); err != nil {
+				return err
+			}
+		}
+		for _, q := range sub {
+			if err = expected.Sub(q); err != nil {
+				return fmt.Errorf("%s balance underflow: %w", addr, err)
+			}
+		}
+		if after.Cmp(expected) != 0 {
+			return fmt.Errorf("%s balance of '%s' is wrong (expected: %s got: %s)", addr, denom, expected, after)
+		}
+		return nil
+	}
+
+	// The sender pays both the amount and the fee.
+	if amount.Denomination == fee.Denomination {
+		if err = checkDelta(from, amount.Denomination, nil, []*quantity.Quantity{&amount.Amount, &fee.Amount}); err != nil {
+			return err
+		}
+	} else {
+		if err = checkDelta(from, amount.Denomination, nil, []*quantity.Quantity{&amount.Amount}); err != nil {
+			return err
+		}
+		if err = checkDelta(from, fee.Denomination, nil, []*quantity.Quantity{&fee.Amount}); err != nil {
+			return err
+		}
+	}
+	if err = checkDelta(to, amount.Denomination, []*quantity.Quantity{&amount.Amount}, nil); err != nil {
+		return err
+	}
+
+	// The fee accumulator only holds the fees paid in the current round as the fees of the
+	// previous round are disbursed at the end of each round.
+	accumulated, err := balanceOf(round, accounts.FeeAccumulatorAddress, fee.Denomination)
+	if err != nil {
+		return err
+	}
+	if accumulated.Cmp(&fee.Amount) != 0 {
+		return fmt.Errorf("fee accumulator balance is wrong (expected: %s got: %s)", fee.Amount.String(), accumulated)
+	}
+
+	return nil
+}
+
 // kvInsert inserts given key-value pair into storage.
 func kvInsert(rtc client.RuntimeClient, signer signature.Signer, key, value []byte) error {
 	ctx := context.Background()
@@ -528,6 +656,50 @@ func KVMultisigTest(sc *RuntimeScenario, log *logging.Logger, conn *grpc.ClientC
 	return nil
 }
 
+// KVNonceHistoryTest checks that historical nonce queries never decrease across rounds.
+func KVNonceHistoryTest(sc *RuntimeScenario, log *logging.Logger, conn *grpc.ClientConn, rtc client.RuntimeClient) error {
+	ctx := context.Background()
+	ac := accounts.NewV1(rtc)
+
+	genesis, err := rtc.GetGenesisBlock(ctx)
+	if err != nil {
+		return err
+	}
+	latest, err := rtc.GetBlock(ctx, client.RoundLatest)
+	if err != nil {
+		return err
+	}
+
+	log.Info("checking Alice's historical nonces",
+		"from_round", genesis.Header.Round,
+		"to_round", latest.Header.Round,
+	)
+	var lastNonce uint64
+	for round := genesis.Header.Round; round <= latest.Header.Round; round++ {
+		nonce, err := ac.Nonce(ctx, round, testing.Alice.Address)
+		if err != nil {
+			return fmt.Errorf("failed to query nonce at round %d: %w", round, err)
+		}
+		if nonce < lastNonce {
+			return fmt.Errorf("nonce decreased at round %d (from %d to %d)", round, lastNonce, nonce)
+		}
+		lastNonce = nonce
+	}
+
+	nonce, err := ac.Nonce(ctx, client.RoundLatest, testing.Alice.Address)
+	if err != nil {
+		return err
+	}
+	if nonce != lastNonce {
+		return fmt.Errorf("nonce at latest round mismatch (expected: %d got: %d)", lastNonce, nonce)
+	}
+	if lastNonce == 0 {
+		return fmt.Errorf("nonce should have increased since genesis")
+	}
+
+	return nil
+}
+
 func KVRewardsTest(sc *RuntimeScenario, log *logging.Logger, conn *grpc.ClientConn, rtc client.RuntimeClient) error {
 	ctx := context.Background()
 	rw := rewards.NewV1(rtc)
@@ -551,6 +723,17 @@ func KVRewardsTest(sc *RuntimeScenario, log *logging.Logger, conn *grpc.ClientCo
 	return nil
 }
 
+// KVTransferVerifyTest does a transfer paying a fee and verifies the resulting balance changes.
+func KVTransferVerifyTest(sc *RuntimeScenario, log *logging.Logger, conn *grpc.ClientConn, rtc client.RuntimeClient) error {
+	ctx := context.Background()
+
+	log.Info("transferring 10 units from Alice to Charlie with a fee of 5 units")
+	return transferAndVerify(ctx, rtc, testing.Alice.Signer, testing.Charlie.Address,
+		types.NewBaseUnits(*quantity.NewFromUint64(10), types.NativeDenomination),
+		types.NewBaseUnits(*quantity.NewFromUint64(5), types.NativeDenomination),
+	)
+}
+
 // KVTxGenTest generates random transactions.
 func KVTxGenTest(sc *RuntimeScenario, log *logging.Logger, conn *grpc.ClientConn, rtc client.RuntimeClient) error {
 	ctx := context.Background()
@@ -20,7 +20,7 @@ import (
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/secp256k1"
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 )
 
@@ -91,34 +91,11 @@ func CheckInvariants(ctx context.Context, rtc client.RuntimeClient) error {
 // SignAndSubmitTx signs and submits the given transaction.
 // Gas estimation is done automatically.
 func SignAndSubmitTx(ctx context.Context, rtc client.RuntimeClient, signer signature.Signer, tx types.Transaction) error {
-	// Get chain context.
-	chainCtx, err := GetChainContext(ctx, rtc)
-	if err != nil {
-		return err
-	}
-
-	// Get current nonce for the signer's account.
-	ac := accounts.NewV1(rtc)
-	nonce, err := ac.Nonce(ctx, client.RoundLatest, types.NewAddress(signer.Public()))
-	if err != nil {
-		return err
-	}
-	tx.AppendAuthSignature(signer.Public(), nonce)
-
-	// Estimate gas.
-	etx := EstimateGas(ctx, rtc, tx)
-
-	// Sign the transaction.
-	stx := etx.PrepareForSigning()
-	if err = stx.AppendSign(chainCtx, signer); err != nil {
-		return err
-	}
-
-	// Submit the signed transaction.
-	if _, err = rtc.SubmitTx(ctx, stx.UnverifiedTransaction()); err != nil {
-		return err
-	}
-	return nil
+	_, err := helpers.SignAndSubmit(ctx, rtc, signer, &tx, &helpers.SignAndSubmitOptions{
+		EstimationGasLimit:          highGasAmount,
+		FallbackOnEstimationFailure: true,
+	})
+	return err
 }
 
 // CreateAndFundAccount creates a new account and funds it using the